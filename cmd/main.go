@@ -5,12 +5,16 @@ import (
 	"cardano-tx-sync/config"
 	"cardano-tx-sync/internal/api"
 	"cardano-tx-sync/internal/chainsync"
+	"cardano-tx-sync/internal/encoder"
 	"cardano-tx-sync/internal/handler"
 	"cardano-tx-sync/internal/kafka"
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/slottime"
 	"cardano-tx-sync/internal/storage"
 	"context"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	"github.com/SundaeSwap-finance/ogmigo"
@@ -28,6 +32,20 @@ func main() {
 		logger.Fatal("cannot load config", zap.Error(err))
 	}
 
+	networkName, networkMagic, err := cfg.Network.Resolve()
+	if err != nil {
+		logger.Fatal("invalid network configuration", zap.Error(err))
+	}
+	network := model.Network{Name: networkName, Magic: networkMagic}
+
+	if err := encoder.Configure(cfg.Encoder); err != nil {
+		logger.Fatal("failed to configure encoders", zap.Error(err))
+	}
+
+	if err := cfg.Kafka.Validate(); err != nil {
+		logger.Fatal("invalid kafka configuration", zap.Error(err))
+	}
+
 	// Set up context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -50,7 +68,10 @@ func main() {
 	logger.Info("database connection established")
 
 	// Initialize Kafka producer
-	producer, err := kafka.NewProducer(cfg.Kafka.Brokers)
+	producer, err := kafka.NewProducer(cfg.Kafka, map[string]string{
+		"network":       network.Name,
+		"network-magic": strconv.FormatUint(uint64(network.Magic), 10),
+	})
 	if err != nil {
 		logger.Fatal("failed to create kafka producer", zap.Error(err))
 	}
@@ -63,11 +84,28 @@ func main() {
 		// ogmigo.WithLogger(zap.NewNop().Sugar()), // Replace with a proper logger if needed
 	)
 
+	// Make sure we are not publishing another network's transactions under this network's identity
+	if err := chainsync.VerifyNetworkMagic(ctx, ogmigoClient, network.Magic); err != nil {
+		logger.Fatal("network check failed", zap.Error(err))
+	}
+	logger.Info("connected to network", zap.String("network", network.Name), zap.Uint32("magic", network.Magic))
+
+	// Load the era summaries used to convert slots to time
+	clock, err := slottime.NewClock(ogmigoClient)
+	if err != nil {
+		logger.Fatal("failed to load era summaries", zap.Error(err))
+	}
+
 	// Initialize block handler
-	blockHandler := handler.NewBlockHandler(db, producer, logger)
+	blockHandler := handler.NewBlockHandler(db, producer, logger, network, clock, ogmigoClient, cfg.Kafka.BlockTopic, cfg.Kafka.DeadLetterTopic)
+
+	// Load the unspent outputs of watched addresses that were not backfilled yet
+	if err := blockHandler.BackfillMappings(ctx); err != nil {
+		logger.Warn("failed to backfill tracked outputs", zap.Error(err))
+	}
 
 	// Initialize ChainSync service
-	syncer := chainsync.NewSyncer(ogmigoClient, blockHandler, db, logger, cfg.ChainSync)
+	syncer := chainsync.NewSyncer(ogmigoClient, blockHandler, db, clock, logger, cfg.ChainSync, cfg.Ogmios)
 
 	// Start the ChainSync service in a separate goroutine
 	go func() {
@@ -77,7 +115,7 @@ func main() {
 	}()
 
 	// Initialize and start API server
-	apiServer := api.NewServer(db, syncer, logger)
+	apiServer := api.NewServer(db, syncer, blockHandler, producer, logger, cfg.API.Health)
 	go func() {
 		if err := apiServer.Start(cfg.API.ListenAddress); err != nil {
 			logger.Error("api server failed to start", zap.Error(err))
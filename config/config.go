@@ -2,16 +2,60 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 // Config holds the application configuration
 type Config struct {
+	Network   NetworkConfig   `mapstructure:"network"`
 	Ogmios    OgmiosConfig    `mapstructure:"ogmios"`
 	Kafka     KafkaConfig     `mapstructure:"kafka"`
 	DB        PostgresConfig  `mapstructure:"db"`
 	API       APIConfig       `mapstructure:"api"`
 	ChainSync ChainSyncConfig `mapstructure:"chainsync"`
+	Encoder   EncoderConfig   `mapstructure:"encoder"`
+}
+
+// NetworkConfig identifies the Cardano network the service syncs from
+type NetworkConfig struct {
+	// Name is one of "mainnet", "preprod", "preview" or "custom". It is required: there is no
+	// default, so that a deployment cannot silently assume the wrong network.
+	Name string `mapstructure:"name"`
+	// Magic is required for "custom" networks and optional otherwise.
+	Magic uint32 `mapstructure:"magic"`
+}
+
+// knownNetworkMagics maps well-known network names to their network magic.
+var knownNetworkMagics = map[string]uint32{
+	"mainnet": 764824073,
+	"preprod": 1,
+	"preview": 2,
+}
+
+// Resolve validates the network configuration and returns its normalized name and magic.
+func (c NetworkConfig) Resolve() (string, uint32, error) {
+	if c.Name == "" {
+		return "", 0, fmt.Errorf("network.name is required: set it to mainnet, preprod, preview or custom")
+	}
+	name := strings.ToLower(c.Name)
+	if name == "custom" {
+		if c.Magic == 0 {
+			return "", 0, fmt.Errorf("network magic is required for a custom network")
+		}
+		return name, c.Magic, nil
+	}
+	magic, ok := knownNetworkMagics[name]
+	if !ok {
+		return "", 0, fmt.Errorf("unknown network %q", c.Name)
+	}
+	if c.Magic != 0 && c.Magic != magic {
+		return "", 0, fmt.Errorf("network magic %d does not match %s (%d)", c.Magic, name, magic)
+	}
+	return name, magic, nil
 }
 
 // OgmiosConfig holds the configuration for Ogmios
@@ -22,6 +66,108 @@ type OgmiosConfig struct {
 // KafkaConfig holds the configuration for Kafka
 type KafkaConfig struct {
 	Brokers []string `mapstructure:"brokers"`
+	// BlockTopic enables the block feed: a header event for every block and a rollback event for
+	// every rollback, published in chain order. Empty disables it.
+	BlockTopic string `mapstructure:"block_topic"`
+	// DeadLetterTopic receives a copy of the messages that could not be encoded or published, which
+	// are always stored in the database. Empty disables it.
+	DeadLetterTopic string `mapstructure:"dead_letter_topic"`
+
+	ClientID string `mapstructure:"client_id"`
+	// Version is the Kafka protocol version to use, e.g. "2.8.0". Empty uses the client default.
+	Version string          `mapstructure:"version"`
+	TLS     KafkaTLSConfig  `mapstructure:"tls"`
+	SASL    KafkaSASLConfig `mapstructure:"sasl"`
+
+	// Async publishes the messages of a block in one batch without waiting for each ack in turn.
+	// The whole batch is still acknowledged before the block is checkpointed. Only one request is in
+	// flight per broker, so that retries keep messages in order within a partition.
+	Async bool `mapstructure:"async"`
+
+	// RequiredAcks is "all", "leader" or "none".
+	RequiredAcks string `mapstructure:"required_acks"`
+	MaxRetries   int    `mapstructure:"max_retries"`
+	// Idempotent enables the idempotent producer, which requires RequiredAcks "all" and Kafka 0.11+.
+	Idempotent bool `mapstructure:"idempotent"`
+	// Compression is "none", "gzip", "snappy", "lz4" or "zstd".
+	Compression     string `mapstructure:"compression"`
+	MaxMessageBytes int    `mapstructure:"max_message_bytes"`
+	// Linger is how long messages are buffered to be sent in batches. Zero sends them immediately.
+	Linger time.Duration `mapstructure:"linger"`
+	// BatchSize and BatchBytes send a batch once it holds that many messages or bytes, before
+	// Linger expires. Zero means no limit.
+	BatchSize  int `mapstructure:"batch_size"`
+	BatchBytes int `mapstructure:"batch_bytes"`
+}
+
+// KafkaTLSConfig holds the TLS settings of the Kafka connection
+type KafkaTLSConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// CAFile verifies the brokers with a custom CA instead of the system roots.
+	CAFile string `mapstructure:"ca_file"`
+	// CertFile and KeyFile are the client certificate and key, for mutual TLS.
+	CertFile           string `mapstructure:"cert_file"`
+	KeyFile            string `mapstructure:"key_file"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+// KafkaSASLConfig holds the SASL authentication settings of the Kafka connection
+type KafkaSASLConfig struct {
+	// Mechanism is "PLAIN", "SCRAM-SHA-256" or "SCRAM-SHA-512". Empty disables SASL.
+	Mechanism string `mapstructure:"mechanism"`
+	Username  string `mapstructure:"username"`
+	Password  string `mapstructure:"password"`
+}
+
+// Validate checks the Kafka configuration for unknown values and inconsistent settings.
+func (c KafkaConfig) Validate() error {
+	if len(c.Brokers) == 0 {
+		return fmt.Errorf("at least one broker is required")
+	}
+
+	switch c.RequiredAcks {
+	case "all", "leader", "none":
+	default:
+		return fmt.Errorf("unknown required_acks %q", c.RequiredAcks)
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("max_retries must not be negative")
+	}
+	if c.Idempotent {
+		if c.RequiredAcks != "all" {
+			return fmt.Errorf("the idempotent producer requires required_acks \"all\"")
+		}
+		if c.MaxRetries == 0 {
+			return fmt.Errorf("the idempotent producer requires max_retries > 0")
+		}
+	}
+
+	switch c.Compression {
+	case "none", "gzip", "snappy", "lz4", "zstd":
+	default:
+		return fmt.Errorf("unknown compression %q", c.Compression)
+	}
+	if c.MaxMessageBytes < 0 || c.Linger < 0 || c.BatchSize < 0 || c.BatchBytes < 0 {
+		return fmt.Errorf("max_message_bytes, linger, batch_size and batch_bytes must not be negative")
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return fmt.Errorf("tls.cert_file and tls.key_file must be set together")
+	}
+	if !c.TLS.Enabled && (c.TLS.CAFile != "" || c.TLS.CertFile != "" || c.TLS.InsecureSkipVerify) {
+		return fmt.Errorf("tls settings require tls.enabled")
+	}
+
+	switch c.SASL.Mechanism {
+	case "":
+	case "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512":
+		if c.SASL.Username == "" || c.SASL.Password == "" {
+			return fmt.Errorf("sasl.username and sasl.password are required for %s", c.SASL.Mechanism)
+		}
+	default:
+		return fmt.Errorf("unknown sasl.mechanism %q", c.SASL.Mechanism)
+	}
+	return nil
 }
 
 // PostgresConfig holds the configuration for the PostgreSQL database
@@ -36,7 +182,15 @@ type PostgresConfig struct {
 
 // APIConfig holds the configuration for the API server
 type APIConfig struct {
-	ListenAddress string `mapstructure:"listen_address"`
+	ListenAddress string       `mapstructure:"listen_address"`
+	Health        HealthConfig `mapstructure:"health"`
+}
+
+// HealthConfig holds the configuration for the liveness and readiness probes
+type HealthConfig struct {
+	// MaxBlockAge is how long the syncer may go without processing a block before it is
+	// considered wedged. Zero disables the check.
+	MaxBlockAge time.Duration `mapstructure:"max_block_age"`
 }
 
 // ChainSyncConfig holds the configuration for the chainsync process
@@ -44,12 +198,39 @@ type ChainSyncConfig struct {
 	MaxCheckpointsToKeep int `mapstructure:"max_checkpoints_to_keep"`
 }
 
+// EncoderConfig holds the configuration shared by the message encoders
+type EncoderConfig struct {
+	// LegacyInt64Quantities makes the DANOGO encoders publish asset quantities as int64
+	// (which overflow above 2^63-1), and the DEFAULT and PROJECTION encoders as JSON numbers,
+	// instead of lossless decimal strings.
+	LegacyInt64Quantities bool       `mapstructure:"legacy_int64_quantities"`
+	Avro                  AvroConfig `mapstructure:"avro"`
+}
+
+// AvroConfig holds the configuration for the AVRO encoder and its schema registry
+type AvroConfig struct {
+	// SchemaRegistryURL enables the AVRO encoder when set.
+	SchemaRegistryURL string        `mapstructure:"schema_registry_url"`
+	Subject           string        `mapstructure:"subject"`
+	Username          string        `mapstructure:"username"`
+	Password          string        `mapstructure:"password"`
+	Timeout           time.Duration `mapstructure:"timeout"`
+}
+
 // LoadConfig reads configuration from file or environment variables.
 func LoadConfig(path string) (config Config, err error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 
+	viper.SetDefault("api.health.max_block_age", "10m")
+	viper.SetDefault("encoder.avro.subject", "cardano.txsync.TxnMessage")
+	viper.SetDefault("encoder.avro.timeout", "10s")
+	viper.SetDefault("kafka.client_id", "cardano-tx-sync")
+	viper.SetDefault("kafka.required_acks", "all")
+	viper.SetDefault("kafka.max_retries", 5)
+	viper.SetDefault("kafka.compression", "none")
+
 	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
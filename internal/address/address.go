@@ -0,0 +1,206 @@
+// internal/address/address.go
+package address
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CredentialType tells whether a credential is a verification key hash or a script hash.
+type CredentialType string
+
+const (
+	// CredentialKey is a verification key hash credential.
+	CredentialKey CredentialType = "key"
+	// CredentialScript is a script hash credential.
+	CredentialScript CredentialType = "script"
+)
+
+// credentialLength is the length of a credential hash (Blake2b-224).
+const credentialLength = 28
+
+// Credential is a payment or stake credential.
+type Credential struct {
+	Type CredentialType `json:"type"`
+	Hash string         `json:"hash"`
+}
+
+// Address is a decoded Shelley-era address.
+type Address struct {
+	// Header is the header type, from bits 7-4 of the first byte (CIP-19).
+	Header    byte
+	NetworkID byte
+	Payment   *Credential
+	Stake     *Credential
+}
+
+// Parse decodes a bech32 Shelley address.
+func Parse(addr string) (*Address, error) {
+	_, raw, err := decodeBech32(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bech32 address: %w", err)
+	}
+	return parseBytes(raw)
+}
+
+func parseBytes(raw []byte) (*Address, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("empty address")
+	}
+	a := &Address{Header: raw[0] >> 4, NetworkID: raw[0] & 0x0f}
+	body := raw[1:]
+
+	switch a.Header {
+	case 0, 1, 2, 3: // base addresses
+		if len(body) != 2*credentialLength {
+			return nil, fmt.Errorf("invalid base address length %d", len(raw))
+		}
+		a.Payment = credential(a.Header&1 == 1, body[:credentialLength])
+		a.Stake = credential(a.Header&2 == 2, body[credentialLength:])
+	case 4, 5: // pointer addresses
+		if len(body) <= credentialLength {
+			return nil, fmt.Errorf("invalid pointer address length %d", len(raw))
+		}
+		a.Payment = credential(a.Header == 5, body[:credentialLength])
+	case 6, 7: // enterprise addresses
+		if len(body) != credentialLength {
+			return nil, fmt.Errorf("invalid enterprise address length %d", len(raw))
+		}
+		a.Payment = credential(a.Header == 7, body)
+	case 14, 15: // reward addresses
+		if len(body) != credentialLength {
+			return nil, fmt.Errorf("invalid reward address length %d", len(raw))
+		}
+		a.Stake = credential(a.Header == 15, body)
+	default:
+		return nil, fmt.Errorf("unsupported address header type %d", a.Header)
+	}
+	return a, nil
+}
+
+func credential(script bool, hash []byte) *Credential {
+	c := &Credential{Type: CredentialKey, Hash: hex.EncodeToString(hash)}
+	if script {
+		c.Type = CredentialScript
+	}
+	return c
+}
+
+// PaymentScriptHash returns the payment script hash of an address, if it is locked by a script.
+func PaymentScriptHash(addr string) (string, bool) {
+	a, err := Parse(addr)
+	if err != nil || a.Payment == nil || a.Payment.Type != CredentialScript {
+		return "", false
+	}
+	return a.Payment.Hash, true
+}
+
+// StakeCredentialHash returns the stake credential hash of an address, if it has one. Pointer
+// addresses reference their stake credential by chain position and have none.
+func StakeCredentialHash(addr string) (string, bool) {
+	a, err := Parse(addr)
+	if err != nil || a.Stake == nil {
+		return "", false
+	}
+	return a.Stake.Hash, true
+}
+
+// NormalizeStakeCredential returns the hex-encoded hash of a stake credential given as a hash or
+// as a bech32 stake address.
+func NormalizeStakeCredential(s string) (string, error) {
+	if raw, err := hex.DecodeString(s); err == nil && len(raw) == credentialLength {
+		return strings.ToLower(s), nil
+	}
+	a, err := Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("not a stake credential hash or stake address: %w", err)
+	}
+	if a.Stake == nil {
+		return "", errors.New("address has no stake credential")
+	}
+	return a.Stake.Hash, nil
+}
+
+// Type is the kind of an address.
+type Type string
+
+const (
+	TypeBase       Type = "base"
+	TypePointer    Type = "pointer"
+	TypeEnterprise Type = "enterprise"
+	TypeReward     Type = "reward"
+	TypeByron      Type = "byron"
+)
+
+// Details is the human-readable decoding of an address, as published by the encoders.
+type Details struct {
+	Type              Type        `json:"type"`
+	NetworkID         byte        `json:"networkId"`
+	PaymentCredential *Credential `json:"paymentCredential,omitempty"`
+	StakeCredential   *Credential `json:"stakeCredential,omitempty"`
+	// StakeAddress is the bech32 reward address of the stake credential.
+	StakeAddress string `json:"stakeAddress,omitempty"`
+}
+
+// Type returns the kind of a Shelley address from its header.
+func (a *Address) Type() Type {
+	switch {
+	case a.Header <= 3:
+		return TypeBase
+	case a.Header <= 5:
+		return TypePointer
+	case a.Header <= 7:
+		return TypeEnterprise
+	default:
+		return TypeReward
+	}
+}
+
+// StakeAddress returns the bech32 reward address of the stake credential, if any.
+func (a *Address) StakeAddress() (string, error) {
+	if a.Stake == nil {
+		return "", nil
+	}
+	hash, err := hex.DecodeString(a.Stake.Hash)
+	if err != nil {
+		return "", err
+	}
+	header := byte(0xe0) | a.NetworkID
+	if a.Stake.Type == CredentialScript {
+		header |= 0x10
+	}
+	hrp := "stake_test"
+	if a.NetworkID == 1 {
+		hrp = "stake"
+	}
+	return encodeBech32(hrp, append([]byte{header}, hash...))
+}
+
+// Describe decodes a bech32 Shelley address or a base58 Byron address.
+func Describe(addr string) (*Details, error) {
+	a, err := Parse(addr)
+	if err == nil {
+		return details(a)
+	}
+	networkID, byronErr := parseByron(addr)
+	if byronErr != nil {
+		return nil, err
+	}
+	return &Details{Type: TypeByron, NetworkID: networkID}, nil
+}
+
+func details(a *Address) (*Details, error) {
+	stakeAddress, err := a.StakeAddress()
+	if err != nil {
+		return nil, err
+	}
+	return &Details{
+		Type:              a.Type(),
+		NetworkID:         a.NetworkID,
+		PaymentCredential: a.Payment,
+		StakeCredential:   a.Stake,
+		StakeAddress:      stakeAddress,
+	}, nil
+}
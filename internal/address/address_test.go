@@ -0,0 +1,185 @@
+// internal/address/address_test.go
+package address
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+)
+
+// CIP-19 test vectors: the credentials are the payment key hash, the stake key hash and the script
+// hash below, and pointer addresses point at slot 2498243, transaction 27, certificate 3.
+const (
+	paymentKeyHash = "9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e"
+	stakeKeyHash   = "337b62cfff6403a06a3acbc34f8c46003c69fe79a3628cefa9c47251"
+	scriptHash     = "c37b1b5dc0669f1d3c61a6fddb2e8fde96be87b881c60bce8e8d542f"
+)
+
+var (
+	paymentKey    = &Credential{Type: CredentialKey, Hash: paymentKeyHash}
+	stakeKey      = &Credential{Type: CredentialKey, Hash: stakeKeyHash}
+	script        = &Credential{Type: CredentialScript, Hash: scriptHash}
+	mainnetStake  = "stake1uyehkck0lajq8gr28t9uxnuvgcqrc6070x3k9r8048z8y5gh6ffgw"
+	mainnetScript = "stake178phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtcccycj5"
+	testnetStake  = "stake_test1uqehkck0lajq8gr28t9uxnuvgcqrc6070x3k9r8048z8y5gssrtvn"
+	testnetScript = "stake_test17rphkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtcljw6kf"
+)
+
+func TestDescribeCIP19(t *testing.T) {
+	tests := []struct {
+		address string
+		want    Details
+	}{
+		// Mainnet
+		{"addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x",
+			Details{TypeBase, 1, paymentKey, stakeKey, mainnetStake}},
+		{"addr1z8phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gten0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgs9yc0hh",
+			Details{TypeBase, 1, script, stakeKey, mainnetStake}},
+		{"addr1yx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzerkr0vd4msrxnuwnccdxlhdjar77j6lg0wypcc9uar5d2shs2z78ve",
+			Details{TypeBase, 1, paymentKey, script, mainnetScript}},
+		{"addr1x8phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gt7r0vd4msrxnuwnccdxlhdjar77j6lg0wypcc9uar5d2shskhj42g",
+			Details{TypeBase, 1, script, script, mainnetScript}},
+		{"addr1gx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer5pnz75xxcrzqf96k", Details{TypePointer, 1, paymentKey, nil, ""}},
+		{"addr128phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtupnz75xxcrtw79hu", Details{TypePointer, 1, script, nil, ""}},
+		{"addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8", Details{TypeEnterprise, 1, paymentKey, nil, ""}},
+		{"addr1w8phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtcyjy7wx", Details{TypeEnterprise, 1, script, nil, ""}},
+		{mainnetStake, Details{TypeReward, 1, nil, stakeKey, mainnetStake}},
+		{mainnetScript, Details{TypeReward, 1, nil, script, mainnetScript}},
+		// Testnets
+		{"addr_test1qz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgs68faae",
+			Details{TypeBase, 0, paymentKey, stakeKey, testnetStake}},
+		{"addr_test1zrphkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gten0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgsxj90mg",
+			Details{TypeBase, 0, script, stakeKey, testnetStake}},
+		{"addr_test1yz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzerkr0vd4msrxnuwnccdxlhdjar77j6lg0wypcc9uar5d2shsf5r8qx",
+			Details{TypeBase, 0, paymentKey, script, testnetScript}},
+		{"addr_test1xrphkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gt7r0vd4msrxnuwnccdxlhdjar77j6lg0wypcc9uar5d2shs4p04xh",
+			Details{TypeBase, 0, script, script, testnetScript}},
+		{"addr_test1gz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer5pnz75xxcrdw5vky", Details{TypePointer, 0, paymentKey, nil, ""}},
+		{"addr_test12rphkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtupnz75xxcryqrvmw", Details{TypePointer, 0, script, nil, ""}},
+		{"addr_test1vz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzerspjrlsz", Details{TypeEnterprise, 0, paymentKey, nil, ""}},
+		{"addr_test1wrphkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtcl6szpr", Details{TypeEnterprise, 0, script, nil, ""}},
+		{testnetStake, Details{TypeReward, 0, nil, stakeKey, testnetStake}},
+		{testnetScript, Details{TypeReward, 0, nil, script, testnetScript}},
+		// Byron: Icarus and Daedalus mainnet addresses, and a testnet address carrying its
+		// protocol magic.
+		{"Ae2tdPwUPEYwFx4dmJheyNPPYXtvHbJLeCaA96o6Y2iiUL18cAt7AizN2zG", Details{Type: TypeByron, NetworkID: 1}},
+		{"DdzFFzCqrhsrcTVhLygT24QwTnNqQqQ8mZrq5jykUzMveU26sxaH529kMpo7VhPrt5pwW3dXeB2k3EEvKcNBRmzCfcQ7dTkyGzTs658C",
+			Details{Type: TypeByron, NetworkID: 1}},
+		{"37btjrVyb4KDXBNC4haBVPCrro8AQPHwvCMp3RFhhSVWwfFmZ6wwzSK6JK1hY6wHNmtrpTf1kdbva8TCneM2YsiXT7mrzT21EacHnPpz5YyUdj64na",
+			Details{Type: TypeByron, NetworkID: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			got, err := Describe(tt.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Type != tt.want.Type || got.NetworkID != tt.want.NetworkID || got.StakeAddress != tt.want.StakeAddress ||
+				!sameCredential(got.PaymentCredential, tt.want.PaymentCredential) || !sameCredential(got.StakeCredential, tt.want.StakeCredential) {
+				t.Errorf("got  %s\nwant %s", describe(got), describe(&tt.want))
+			}
+		})
+	}
+}
+
+func sameCredential(a, b *Credential) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func describe(d *Details) string {
+	credential := func(c *Credential) string {
+		if c == nil {
+			return "none"
+		}
+		return string(c.Type) + ":" + c.Hash
+	}
+	return string(d.Type) + " network " + strconv.Itoa(int(d.NetworkID)) + " payment " + credential(d.PaymentCredential) +
+		" stake " + credential(d.StakeCredential) + " " + d.StakeAddress
+}
+
+func TestDescribeInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+	}{
+		{"bech32 checksum", "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl9"},
+		{"Byron checksum", "Ae2tdPwUPEYwFx4dmJheyNPPYXtvHbJLeCaA96o6Y2iiUL18cAt7AizN2zH"},
+		{"not an address", "hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d, err := Describe(tt.address); err == nil {
+				t.Errorf("decoded %s", describe(d))
+			}
+		})
+	}
+}
+
+func TestStakeCredentialHash(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+		ok      bool
+	}{
+		{"addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x", stakeKeyHash, true},
+		{"addr_test1yz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzerkr0vd4msrxnuwnccdxlhdjar77j6lg0wypcc9uar5d2shsf5r8qx", scriptHash, true},
+		{mainnetStake, stakeKeyHash, true},
+		// Pointer addresses reference their stake credential by chain position.
+		{"addr1gx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer5pnz75xxcrzqf96k", "", false},
+		{"addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8", "", false},
+		{"Ae2tdPwUPEYwFx4dmJheyNPPYXtvHbJLeCaA96o6Y2iiUL18cAt7AizN2zG", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := StakeCredentialHash(tt.address)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("StakeCredentialHash(%s) = %s, %v, want %s, %v", tt.address, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNormalizeStakeCredential(t *testing.T) {
+	for _, s := range []string{stakeKeyHash, "337B62CFFF6403A06A3ACBC34F8C46003C69FE79A3628CEFA9C47251", mainnetStake, testnetStake} {
+		if got, err := NormalizeStakeCredential(s); err != nil || got != stakeKeyHash {
+			t.Errorf("NormalizeStakeCredential(%s) = %s, %v, want %s", s, got, err, stakeKeyHash)
+		}
+	}
+	if _, err := NormalizeStakeCredential("addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8"); err == nil {
+		t.Error("an enterprise address was accepted as a stake credential")
+	}
+}
+
+func TestPoolID(t *testing.T) {
+	// The pool ID is the bech32 encoding of the Blake2b-224 hash of the cold verification key.
+	got, err := PoolID("cafecafecafecafecafecafecafecafecafecafecafecafecafecafecafecafe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "pool1u5twhfvda73ueu82e392rfdk9tlkfmja79vj8suw6qqkj7vr4hp"; got != want {
+		t.Errorf("PoolID = %s, want %s", got, want)
+	}
+
+	// A mainnet pool ID and its hash.
+	id, err := encodeBech32("pool", mustHex(t, "0f292fcaa02b8b2f9b3c8f9fd8e0bb21abedb692a6d5058df3ef2735"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "pool1pu5jlj4q9w9jlxeu370a3c9myx47md5j5m2str0naunn2q3lkdy"; id != want {
+		t.Errorf("pool ID = %s, want %s", id, want)
+	}
+
+	if _, err := PoolID("not hex"); err == nil {
+		t.Error("an invalid verification key was accepted")
+	}
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
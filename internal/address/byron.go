@@ -0,0 +1,79 @@
+// internal/address/byron.go
+package address
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// byronProtocolMagicAttribute is the attribute holding the protocol magic of testnet addresses.
+const byronProtocolMagicAttribute = 2
+
+// parseByron decodes a base58 Byron address, tagged CBOR with a CRC32 checksum:
+// [#6.24(bytes .cbor [root, attributes, type]), crc32]. Mainnet addresses carry no protocol
+// magic, so the network ID is 1 without one and 0 with one.
+func parseByron(addr string) (byte, error) {
+	raw, err := decodeBase58(addr)
+	if err != nil {
+		return 0, err
+	}
+
+	var envelope struct {
+		_       struct{} `cbor:",toarray"`
+		Payload cbor.Tag
+		CRC     uint32
+	}
+	if err := cbor.Unmarshal(raw, &envelope); err != nil {
+		return 0, fmt.Errorf("invalid Byron address: %w", err)
+	}
+	payload, ok := envelope.Payload.Content.([]byte)
+	if envelope.Payload.Number != 24 || !ok {
+		return 0, errors.New("invalid Byron address payload")
+	}
+	if crc32.ChecksumIEEE(payload) != envelope.CRC {
+		return 0, errors.New("invalid Byron address checksum")
+	}
+
+	var body struct {
+		_          struct{} `cbor:",toarray"`
+		Root       []byte
+		Attributes map[uint64]cbor.RawMessage
+		Type       uint64
+	}
+	if err := cbor.Unmarshal(payload, &body); err != nil {
+		return 0, fmt.Errorf("invalid Byron address payload: %w", err)
+	}
+	if _, ok := body.Attributes[byronProtocolMagicAttribute]; ok {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func decodeBase58(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("empty base58 string")
+	}
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(base58Alphabet, s[i])
+		if v < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", s[i])
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(v)))
+	}
+
+	leadingZeros := 0
+	for leadingZeros < len(s) && s[leadingZeros] == base58Alphabet[0] {
+		leadingZeros++
+	}
+	return append(make([]byte, leadingZeros), n.Bytes()...), nil
+}
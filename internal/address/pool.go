@@ -0,0 +1,24 @@
+// internal/address/pool.go
+package address
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// PoolID returns the bech32 pool ID of a stake pool from its cold verification key, the
+// Blake2b-224 hash of the key.
+func PoolID(verificationKey string) (string, error) {
+	key, err := hex.DecodeString(verificationKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid verification key: %w", err)
+	}
+	hash, err := blake2b.New(credentialLength, nil)
+	if err != nil {
+		return "", err
+	}
+	hash.Write(key)
+	return encodeBech32("pool", hash.Sum(nil))
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/plutus"
+	"cardano-tx-sync/internal/storage"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// blueprintSummary describes a blueprint in the response of the add and get endpoints.
+type blueprintSummary struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Title      string   `json:"title"`
+	Version    string   `json:"version"`
+	Validators []string `json:"validators"`
+}
+
+func (s *Server) addBlueprint(c *gin.Context) {
+	var req struct {
+		Name      string          `json:"name" binding:"required"`
+		Blueprint json.RawMessage `json:"blueprint" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	parsed, err := plutus.ParseBlueprint(req.Blueprint)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	blueprint := model.Blueprint{Name: req.Name, Blueprint: req.Blueprint}
+	var summary blueprintSummary
+	_, err = s.storage.AddBlueprint(blueprint, func(id int) model.AuditEntry {
+		blueprint.ID = id
+		summary = summarizeBlueprint(blueprint, parsed)
+		return s.auditEntry(c, model.AuditActionCreate, model.AuditEntityBlueprint, strconv.Itoa(id), nil, summary)
+	})
+	if err != nil {
+		s.logger.Error("failed to add blueprint", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add blueprint"})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (s *Server) listBlueprints(c *gin.Context) {
+	blueprints, err := s.storage.ListBlueprints()
+	if err != nil {
+		s.logger.Error("failed to list blueprints", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list blueprints"})
+		return
+	}
+	if blueprints == nil {
+		blueprints = []model.Blueprint{}
+	}
+
+	c.JSON(http.StatusOK, blueprints)
+}
+
+func (s *Server) getBlueprint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	blueprint, err := s.storage.GetBlueprint(id)
+	if err != nil {
+		s.logger.Error("failed to get blueprint", zap.Error(err), zap.Int("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get blueprint"})
+		return
+	}
+	if blueprint == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "blueprint not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, blueprint)
+}
+
+func (s *Server) removeBlueprint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	before, err := s.storage.GetBlueprint(id)
+	if err != nil {
+		s.logger.Error("failed to get blueprint", zap.Error(err), zap.Int("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove blueprint"})
+		return
+	}
+	if before == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "blueprint not found"})
+		return
+	}
+
+	var summary interface{} = before
+	if parsed, err := plutus.ParseBlueprint(before.Blueprint); err == nil {
+		summary = summarizeBlueprint(*before, parsed)
+	}
+	audit := s.auditEntry(c, model.AuditActionDelete, model.AuditEntityBlueprint, strconv.Itoa(id), summary, nil)
+	if err := s.storage.RemoveBlueprint(id, audit); err != nil {
+		if errors.Is(err, storage.ErrBlueprintInUse) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		s.logger.Error("failed to remove blueprint", zap.Error(err), zap.Int("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove blueprint"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func summarizeBlueprint(blueprint model.Blueprint, parsed *plutus.Blueprint) blueprintSummary {
+	validators := make([]string, len(parsed.Validators))
+	for i, v := range parsed.Validators {
+		validators[i] = v.Title
+	}
+	return blueprintSummary{
+		ID:         blueprint.ID,
+		Name:       blueprint.Name,
+		Title:      parsed.Preamble.Title,
+		Version:    parsed.Preamble.Version,
+		Validators: validators,
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"cardano-tx-sync/internal/handler"
+	"cardano-tx-sync/internal/model"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func (s *Server) listDeadLetters(c *gin.Context) {
+	filter := model.DeadLetterFilter{
+		TxID:  c.Query("tx_id"),
+		Limit: 100,
+	}
+	if pendingStr := c.Query("pending"); pendingStr != "" {
+		pending, err := strconv.ParseBool(pendingStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending"})
+			return
+		}
+		filter.Pending = pending
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > 1000 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		filter.Limit = limit
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+			return
+		}
+		filter.Offset = offset
+	}
+
+	entries, err := s.storage.GetDeadLetters(filter)
+	if err != nil {
+		s.logger.Error("failed to get dead letters", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get dead letters"})
+		return
+	}
+	if entries == nil {
+		entries = []model.DeadLetter{}
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (s *Server) getDeadLetter(c *gin.Context) {
+	entry, ok := s.loadDeadLetter(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+// redriveOutcome is the audited result of a re-drive attempt.
+type redriveOutcome struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// redriveDeadLetter encodes a dead letter again with the current settings of its mapping and
+// publishes it. Dead letters can be re-driven until one attempt succeeds. The dead letter is
+// claimed first, so that concurrent requests do not publish it twice.
+func (s *Server) redriveDeadLetter(c *gin.Context) {
+	loaded, ok := s.loadDeadLetter(c)
+	if !ok {
+		return
+	}
+	entry, err := s.storage.ClaimDeadLetter(loaded.ID)
+	if err != nil {
+		s.logger.Error("failed to claim dead letter", zap.Error(err), zap.Int64("id", loaded.ID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to re-drive dead letter"})
+		return
+	}
+	if entry == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "dead letter was already re-driven or is being re-driven"})
+		return
+	}
+
+	redriveErr := s.handler.Redrive(*entry)
+	outcome := redriveOutcome{Status: "redriven"}
+	if redriveErr != nil {
+		outcome = redriveOutcome{Status: "failed", Error: redriveErr.Error()}
+	}
+	before := redriveOutcome{Status: "pending", Error: entry.Error}
+	audit := s.auditEntry(c, model.AuditActionRedrive, model.AuditEntityDeadLetter, strconv.FormatInt(entry.ID, 10), before, outcome)
+	if err := s.storage.SaveRedriveAttempt(entry.ID, redriveErr, audit); err != nil {
+		s.logger.Error("failed to save re-drive attempt", zap.Error(err), zap.Int64("id", entry.ID))
+	}
+	if errors.Is(redriveErr, handler.ErrMappingRemoved) {
+		c.JSON(http.StatusConflict, gin.H{"error": redriveErr.Error()})
+		return
+	}
+	if redriveErr != nil {
+		s.logger.Error("failed to re-drive dead letter", zap.Error(redriveErr), zap.Int64("id", entry.ID))
+		c.JSON(http.StatusBadGateway, gin.H{"error": redriveErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": entry.ID, "status": "redriven"})
+}
+
+// loadDeadLetter gets the dead letter of the request path. It writes the error response and
+// returns false if there is none.
+func (s *Server) loadDeadLetter(c *gin.Context) (*model.DeadLetter, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return nil, false
+	}
+
+	entry, err := s.storage.GetDeadLetter(id)
+	if err != nil {
+		s.logger.Error("failed to get dead letter", zap.Error(err), zap.Int64("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get dead letter"})
+		return nil, false
+	}
+	if entry == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "dead letter not found"})
+		return nil, false
+	}
+	return entry, true
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"cardano-tx-sync/internal/model"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const checkOK = "ok"
+
+// healthz is the liveness probe. It only fails when the syncer is wedged, so that
+// an outage of a dependency does not cause the process to be restarted in a loop.
+func (s *Server) healthz(c *gin.Context) {
+	checks := map[string]string{
+		"syncer": checkResult(s.checkSyncer()),
+	}
+	respondHealth(c, checks)
+}
+
+// readyz is the readiness probe. It checks every dependency the syncer needs to make progress.
+func (s *Server) readyz(c *gin.Context) {
+	checks := map[string]string{
+		"postgres": checkResult(s.storage.Ping()),
+		"kafka":    checkResult(s.producer.Ping()),
+		"ogmios":   checkResult(s.checkOgmios()),
+		"syncer":   checkResult(s.checkSyncer()),
+	}
+	respondHealth(c, checks)
+}
+
+// checkOgmios reports whether the chainsync connection is established.
+func (s *Server) checkOgmios() error {
+	status := s.syncer.Status()
+	if status.Paused {
+		// The connection is closed on purpose; stay ready so the API can resume it.
+		return nil
+	}
+	if status.State != model.SyncStateSyncing {
+		return fmt.Errorf("chainsync is %s", status.State)
+	}
+	return nil
+}
+
+// checkSyncer reports whether a block has been processed within the configured window.
+// Before the first block, the window is measured from the syncer start. A paused syncer, or one
+// waiting to retry after a failed connection or block, is not wedged: the dependency it waits for
+// is checked by readyz.
+func (s *Server) checkSyncer() error {
+	if s.healthCfg.MaxBlockAge <= 0 {
+		return nil
+	}
+	status := s.syncer.Status()
+	if status.State == model.SyncStateStopped {
+		return fmt.Errorf("syncer has stopped")
+	}
+	if status.Paused || status.State == model.SyncStateReconnecting {
+		return nil
+	}
+	last := status.StartedAt
+	if status.LastProcessedAt != nil {
+		last = *status.LastProcessedAt
+	}
+	if age := time.Since(last); age > s.healthCfg.MaxBlockAge {
+		return fmt.Errorf("no block processed for %s", age.Round(time.Second))
+	}
+	return nil
+}
+
+func checkResult(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return checkOK
+}
+
+func respondHealth(c *gin.Context, checks map[string]string) {
+	for _, result := range checks {
+		if result != checkOK {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "checks": checks})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"status": checkOK, "checks": checks})
+}
@@ -1,23 +1,35 @@
 package api
 
 import (
+	"cardano-tx-sync/config"
+	"cardano-tx-sync/internal/address"
 	"cardano-tx-sync/internal/chainsync"
+	"cardano-tx-sync/internal/encoder"
+	"cardano-tx-sync/internal/handler"
+	"cardano-tx-sync/internal/kafka"
 	"cardano-tx-sync/internal/model"
 	"cardano-tx-sync/internal/storage"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 )
 
 // Server holds the dependencies for the API server.
 type Server struct {
-	storage storage.Storage
-	syncer  *chainsync.Syncer
-	logger  *zap.Logger
-	router  *gin.Engine
+	storage   storage.Storage
+	syncer    *chainsync.Syncer
+	handler   *handler.BlockHandler
+	producer  *kafka.Producer
+	logger    *zap.Logger
+	healthCfg config.HealthConfig
+	router    *gin.Engine
 }
 
 var validMappingTypes = map[model.MappingType]bool{
@@ -26,14 +38,31 @@ var validMappingTypes = map[model.MappingType]bool{
 	model.MappingTypeCert:     true,
 	model.MappingTypeProposal: true,
 	model.MappingTypeVote:     true,
+
+	model.MappingTypeStakeCredential: true,
+}
+
+var validPartitionKeys = map[model.PartitionKeyStrategy]bool{
+	model.PartitionKeyNone:       true,
+	model.PartitionKeyMappingKey: true,
+	model.PartitionKeyTopic:      true,
+
+	model.PartitionKeyTxID:            true,
+	model.PartitionKeyAddress:         true,
+	model.PartitionKeyPolicyID:        true,
+	model.PartitionKeyStakeCredential: true,
+	model.PartitionKeyBlockHash:       true,
 }
 
 // NewServer creates a new API server.
-func NewServer(storage storage.Storage, syncer *chainsync.Syncer, logger *zap.Logger) *Server {
+func NewServer(storage storage.Storage, syncer *chainsync.Syncer, handler *handler.BlockHandler, producer *kafka.Producer, logger *zap.Logger, healthCfg config.HealthConfig) *Server {
 	server := &Server{
-		storage: storage,
-		syncer:  syncer,
-		logger:  logger,
+		storage:   storage,
+		syncer:    syncer,
+		handler:   handler,
+		producer:  producer,
+		logger:    logger,
+		healthCfg: healthCfg,
 	}
 	server.setupRouter()
 	return server
@@ -45,14 +74,39 @@ func (s *Server) setupRouter() {
 	mappings := router.Group("/mappings")
 	{
 		mappings.POST("", s.addMapping)
+		mappings.PUT("/:id", s.updateMapping)
 		mappings.DELETE("/:id", s.removeMapping)
 	}
 
+	blueprints := router.Group("/blueprints")
+	{
+		blueprints.POST("", s.addBlueprint)
+		blueprints.GET("", s.listBlueprints)
+		blueprints.GET("/:id", s.getBlueprint)
+		blueprints.DELETE("/:id", s.removeBlueprint)
+	}
+
 	sync := router.Group("/sync")
 	{
 		sync.POST("/start", s.startSync)
+		sync.GET("/status", s.syncStatus)
+		sync.POST("/pause", s.pauseSync)
+		sync.POST("/resume", s.resumeSync)
 	}
 
+	deadLetters := router.Group("/dead-letters")
+	{
+		deadLetters.GET("", s.listDeadLetters)
+		deadLetters.GET("/:id", s.getDeadLetter)
+		deadLetters.POST("/:id/redrive", s.redriveDeadLetter)
+	}
+
+	router.GET("/encoders", s.listEncoders)
+	router.GET("/audit", s.listAudit)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	router.GET("/healthz", s.healthz)
+	router.GET("/readyz", s.readyz)
+
 	s.router = router
 }
 
@@ -68,35 +122,77 @@ func (s *Server) addMapping(c *gin.Context) {
 		return
 	}
 
-	if !validMappingTypes[req.Type] {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mapping type"})
+	if err := validateMapping(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-
-	// For wildcard types, ensure the key is "*"
-	if req.Type == model.MappingTypeProposal || req.Type == model.MappingTypeVote {
-		if req.Key != "*" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "key for proposal or vote mapping must be '*' "})
-			return
-		}
-	}
-
-	// Default encoder if not provided
-	if req.Encoder == "" {
-		req.Encoder = "DEFAULT"
+	if !s.checkBlueprintRef(c, req.BlueprintID) {
+		return
 	}
-	req.Encoder = strings.ToUpper(req.Encoder)
 
-	id, err := s.storage.AddMapping(req)
+	id, err := s.storage.AddMapping(req, func(id int) model.AuditEntry {
+		req.ID = id
+		return s.auditEntry(c, model.AuditActionCreate, model.AuditEntityMapping, strconv.Itoa(id), nil, req)
+	})
 	if err != nil {
 		s.logger.Error("failed to add mapping", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add mapping"})
 		return
 	}
 
+	// Without a backfill, BALANCE skips transactions of the address with unresolved inputs; it is
+	// retried at startup.
+	req.ID = id
+	if err := s.handler.Backfill(c.Request.Context(), req); err != nil {
+		s.logger.Warn("failed to backfill tracked outputs", zap.Int("id", id), zap.Error(err))
+	}
+
 	c.JSON(http.StatusOK, gin.H{"id": id})
 }
 
+func (s *Server) updateMapping(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req model.Mapping
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := validateMapping(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !s.checkBlueprintRef(c, req.BlueprintID) {
+		return
+	}
+
+	req.ID = id
+	err = s.storage.UpdateMapping(req, func(before model.Mapping) model.AuditEntry {
+		return s.auditEntry(c, model.AuditActionUpdate, model.AuditEntityMapping, strconv.Itoa(id), before, req)
+	})
+	if errors.Is(err, storage.ErrMappingNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "mapping not found"})
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to update mapping", zap.Error(err), zap.Int("id", id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update mapping"})
+		return
+	}
+
+	// The mapping may watch another address, or use an encoder resolving inputs.
+	if err := s.handler.Backfill(c.Request.Context(), req); err != nil {
+		s.logger.Warn("failed to backfill tracked outputs", zap.Int("id", id), zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
 func (s *Server) removeMapping(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
@@ -105,12 +201,19 @@ func (s *Server) removeMapping(c *gin.Context) {
 		return
 	}
 
-	err = s.storage.RemoveMapping(id)
+	err = s.storage.RemoveMapping(id, func(before model.Mapping) model.AuditEntry {
+		return s.auditEntry(c, model.AuditActionDelete, model.AuditEntityMapping, idStr, before, nil)
+	})
+	if errors.Is(err, storage.ErrMappingNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "mapping not found"})
+		return
+	}
 	if err != nil {
 		s.logger.Error("failed to remove mapping", zap.Error(err), zap.Int("id", id))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove mapping"})
 		return
 	}
+	s.handler.ForgetMapping(id)
 
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
@@ -130,7 +233,16 @@ func (s *Server) startSync(c *gin.Context) {
 		Hash: req.Hash,
 	}
 
-	if err := s.syncer.SetStartPoint(point); err != nil {
+	// Capture the current position before it is cleared by SetStartPoint.
+	var before *model.Checkpoint
+	if checkpoints, err := s.storage.GetLatestCheckpoints(1); err != nil {
+		s.logger.Warn("failed to get latest checkpoint for audit", zap.Error(err))
+	} else if len(checkpoints) > 0 {
+		before = &checkpoints[0]
+	}
+
+	audit := s.auditEntry(c, model.AuditActionSetStartPoint, model.AuditEntitySyncPoint, "", before, point)
+	if err := s.syncer.SetStartPoint(point, audit); err != nil {
 		s.logger.Error("failed to set start point", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to set start point"})
 		return
@@ -138,3 +250,167 @@ func (s *Server) startSync(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "sync point updated"})
 }
+
+func (s *Server) syncStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, s.syncer.Status())
+}
+
+func (s *Server) pauseSync(c *gin.Context) {
+	audit := s.auditEntry(c, model.AuditActionPause, model.AuditEntitySyncer, "", nil, nil)
+	if err := s.syncer.Pause(audit); err != nil {
+		s.logger.Error("failed to pause sync", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to pause sync"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "sync paused"})
+}
+
+func (s *Server) resumeSync(c *gin.Context) {
+	audit := s.auditEntry(c, model.AuditActionResume, model.AuditEntitySyncer, "", nil, nil)
+	if err := s.syncer.Resume(audit); err != nil {
+		s.logger.Error("failed to resume sync", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resume sync"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "sync resumed"})
+}
+
+func (s *Server) listAudit(c *gin.Context) {
+	filter := model.AuditFilter{
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		Limit:      100,
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > 1000 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		filter.Limit = limit
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+			return
+		}
+		filter.Offset = offset
+	}
+
+	entries, err := s.storage.GetAuditEntries(filter)
+	if err != nil {
+		s.logger.Error("failed to get audit entries", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get audit entries"})
+		return
+	}
+	if entries == nil {
+		entries = []model.AuditEntry{}
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// checkBlueprintRef checks that a referenced blueprint exists. It writes the error response and
+// returns false otherwise.
+func (s *Server) checkBlueprintRef(c *gin.Context, id *int) bool {
+	if id == nil {
+		return true
+	}
+	blueprint, err := s.storage.GetBlueprint(*id)
+	if err != nil {
+		s.logger.Error("failed to get blueprint", zap.Error(err), zap.Int("id", *id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get blueprint"})
+		return false
+	}
+	if blueprint == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "blueprint not found"})
+		return false
+	}
+	return true
+}
+
+// listEncoders handles the request to list the registered encoders.
+func (s *Server) listEncoders(c *gin.Context) {
+	c.JSON(http.StatusOK, encoder.List())
+}
+
+// validateMapping checks a mapping request and normalizes its encoder name.
+func validateMapping(req *model.Mapping) error {
+	if !validMappingTypes[req.Type] {
+		return errors.New("invalid mapping type")
+	}
+
+	// For wildcard types, ensure the key is "*"
+	if req.Type == model.MappingTypeProposal || req.Type == model.MappingTypeVote {
+		if req.Key != "*" {
+			return errors.New("key for proposal or vote mapping must be '*' ")
+		}
+	}
+
+	// Stake credentials are stored as hashes, and may be given as stake addresses
+	if req.Type == model.MappingTypeStakeCredential {
+		key, err := address.NormalizeStakeCredential(req.Key)
+		if err != nil {
+			return fmt.Errorf("invalid stake credential: %w", err)
+		}
+		req.Key = key
+	}
+
+	// Default encoder if not provided
+	if req.Encoder == "" {
+		req.Encoder = "DEFAULT"
+	}
+	req.Encoder = strings.ToUpper(req.Encoder)
+	if string(req.EncoderOptions) == "null" {
+		req.EncoderOptions = nil
+	}
+
+	if req.PartitionKey == "" {
+		req.PartitionKey = model.PartitionKeyNone
+	}
+	if !validPartitionKeys[req.PartitionKey] {
+		return errors.New("invalid partition key strategy")
+	}
+
+	return encoder.Validate(req.Encoder, req.EncoderOptions)
+}
+
+// auditEntry builds the audit entry of a change, which storage writes together with the change.
+// The API is not authenticated: the change is attributed to the client IP, and the X-Actor header
+// is only recorded as the claimed actor.
+func (s *Server) auditEntry(c *gin.Context, action model.AuditAction, entityType, entityID string, before, after interface{}) model.AuditEntry {
+	entry := model.AuditEntry{
+		ClientIP:   c.ClientIP(),
+		Action:     action,
+		EntityType: entityType,
+	}
+	if actor := c.GetHeader("X-Actor"); actor != "" {
+		entry.ClaimedActor = &actor
+	}
+	if entityID != "" {
+		entry.EntityID = &entityID
+	}
+
+	var err error
+	if entry.Before, err = marshalAuditValue(before); err != nil {
+		s.logger.Error("failed to marshal audit value", zap.Error(err))
+	}
+	if entry.After, err = marshalAuditValue(after); err != nil {
+		s.logger.Error("failed to marshal audit value", zap.Error(err))
+	}
+	return entry
+}
+
+func marshalAuditValue(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil || string(raw) == "null" {
+		return nil, err
+	}
+	return raw, nil
+}
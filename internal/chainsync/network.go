@@ -0,0 +1,30 @@
+package chainsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SundaeSwap-finance/ogmigo"
+)
+
+// VerifyNetworkMagic checks that the node behind Ogmios runs on the expected network,
+// using the network magic from its Shelley genesis configuration.
+func VerifyNetworkMagic(ctx context.Context, client *ogmigo.Client, expected uint32) error {
+	raw, err := client.GenesisConfig(ctx, "shelley")
+	if err != nil {
+		return fmt.Errorf("failed to query genesis configuration: %w", err)
+	}
+
+	var genesis struct {
+		NetworkMagic uint32 `json:"networkMagic"`
+	}
+	if err := json.Unmarshal(raw, &genesis); err != nil {
+		return fmt.Errorf("failed to decode genesis configuration: %w", err)
+	}
+
+	if genesis.NetworkMagic != expected {
+		return fmt.Errorf("ogmios node is on network magic %d, expected %d", genesis.NetworkMagic, expected)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package chainsync
+
+import (
+	"cardano-tx-sync/internal/metrics"
+	"cardano-tx-sync/internal/model"
+	"time"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+)
+
+// rateWindow is the interval over which the blocks/sec rate is measured.
+const rateWindow = 10 * time.Second
+
+// syncStatus tracks the syncer's progress. It is guarded by Syncer.statusMu.
+type syncStatus struct {
+	state           model.SyncState
+	startedAt       time.Time
+	lastBlock       *model.SyncPoint
+	lastProcessedAt time.Time
+	tip             *model.SyncPoint
+
+	windowStart     time.Time
+	windowBlocks    int
+	blocksPerSecond float64
+}
+
+// Status returns a snapshot of the current sync progress.
+func (s *Syncer) Status() model.SyncStatus {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+
+	status := model.SyncStatus{
+		State:           s.status.state,
+		Endpoint:        s.ogmiosCfg.Endpoint,
+		Paused:          s.status.state == model.SyncStatePaused,
+		Reconnecting:    s.status.state == model.SyncStateReconnecting,
+		StartedAt:       s.status.startedAt,
+		BlocksPerSecond: s.status.blocksPerSecond,
+	}
+	if s.status.lastBlock != nil {
+		lastBlock := *s.status.lastBlock
+		status.LastBlock = &lastBlock
+	}
+	if !s.status.lastProcessedAt.IsZero() {
+		lastProcessedAt := s.status.lastProcessedAt
+		status.LastProcessedAt = &lastProcessedAt
+	}
+	if s.status.tip != nil {
+		tip := *s.status.tip
+		status.Tip = &tip
+		if status.LastBlock != nil && tip.Slot > status.LastBlock.Slot {
+			status.SlotLag = tip.Slot - status.LastBlock.Slot
+		}
+	}
+	// Slot lengths differ between eras, e.g. 20 seconds in the Byron era.
+	var timeLag time.Duration
+	if status.SlotLag > 0 {
+		timeLag = s.clock.Time(status.Tip.Slot).Sub(s.clock.Time(status.LastBlock.Slot))
+	}
+	status.EstimatedTimeLag = timeLag.String()
+
+	return status
+}
+
+func (s *Syncer) setState(state model.SyncState) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	s.status.state = state
+}
+
+// setResumePoint records the checkpoint the syncer resumes from, before any block is processed.
+func (s *Syncer) setResumePoint(cp model.Checkpoint) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	s.status.lastBlock = &model.SyncPoint{Slot: cp.Slot, Hash: cp.Hash}
+}
+
+// recordTip stores the node tip reported alongside a chainsync response.
+func (s *Syncer) recordTip(tip *chainsync.PointStruct) {
+	if tip == nil {
+		return
+	}
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	point := &model.SyncPoint{Slot: tip.Slot, Hash: tip.ID}
+	if tip.Height != nil {
+		point.Height = *tip.Height
+	}
+	s.status.tip = point
+	metrics.TipSlot.Set(float64(tip.Slot))
+	s.updateTipLag()
+}
+
+// recordBlock updates the last processed block and the throughput rate.
+func (s *Syncer) recordBlock(block chainsync.Block) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	now := time.Now()
+	if s.status.state != model.SyncStatePaused {
+		s.status.state = model.SyncStateSyncing
+	}
+	s.status.lastBlock = &model.SyncPoint{
+		Slot:   block.Slot,
+		Hash:   block.ID,
+		Height: block.Height,
+		Era:    block.Era,
+	}
+	s.status.lastProcessedAt = now
+	metrics.LastBlockSlot.Set(float64(block.Slot))
+	s.updateTipLag()
+
+	if s.status.windowStart.IsZero() {
+		s.status.windowStart = now
+	}
+	s.status.windowBlocks++
+	if elapsed := now.Sub(s.status.windowStart); elapsed >= rateWindow {
+		s.status.blocksPerSecond = float64(s.status.windowBlocks) / elapsed.Seconds()
+		s.status.windowStart = now
+		s.status.windowBlocks = 0
+	}
+}
+
+// recordRollback moves the last processed block back to the rollback point.
+func (s *Syncer) recordRollback(point *chainsync.Point) {
+	if point == nil {
+		return
+	}
+	pointStruct, ok := point.PointStruct()
+	if !ok {
+		return
+	}
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	metrics.Rollbacks.Inc()
+	if s.status.lastBlock != nil && s.status.lastBlock.Slot >= pointStruct.Slot {
+		metrics.RollbackDepth.Observe(float64(s.status.lastBlock.Slot - pointStruct.Slot))
+	}
+
+	rollbackTo := &model.SyncPoint{Slot: pointStruct.Slot, Hash: pointStruct.ID}
+	if pointStruct.Height != nil {
+		rollbackTo.Height = *pointStruct.Height
+	}
+	s.status.lastBlock = rollbackTo
+	s.status.lastProcessedAt = time.Now()
+	metrics.LastBlockSlot.Set(float64(pointStruct.Slot))
+	s.updateTipLag()
+}
+
+// updateTipLag refreshes the tip lag gauge. The caller must hold statusMu.
+func (s *Syncer) updateTipLag() {
+	if s.status.tip == nil || s.status.lastBlock == nil {
+		return
+	}
+	lag := float64(0)
+	if s.status.tip.Slot > s.status.lastBlock.Slot {
+		lag = float64(s.status.tip.Slot - s.status.lastBlock.Slot)
+	}
+	metrics.TipLagSlots.Set(lag)
+}
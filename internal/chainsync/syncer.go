@@ -5,6 +5,7 @@ import (
 	"cardano-tx-sync/config"
 	"cardano-tx-sync/internal/handler"
 	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/slottime"
 	"cardano-tx-sync/internal/storage"
 	"context"
 	"encoding/json"
@@ -17,36 +18,50 @@ import (
 	"go.uber.org/zap"
 )
 
+// pausedSettingKey is the settings key under which the paused flag is persisted.
+const pausedSettingKey = "chainsync.paused"
+
 // Syncer manages the chain synchronization with Ogmios.
 type Syncer struct {
 	ogmigoClient *ogmigo.Client
 	handler      *handler.BlockHandler
 	storage      storage.Storage
+	clock        *slottime.Clock
 	logger       *zap.Logger
 	cfg          config.ChainSyncConfig
+	ogmiosCfg    config.OgmiosConfig
 	startPoint   *model.Checkpoint
 	mu           sync.Mutex
 	closer       *ogmigo.ChainSync
+	paused       bool
+	resumeCh     chan struct{}
+
+	statusMu sync.RWMutex
+	status   syncStatus
 }
 
 // NewSyncer creates a new Syncer.
-func NewSyncer(client *ogmigo.Client, handler *handler.BlockHandler, storage storage.Storage, logger *zap.Logger, cfg config.ChainSyncConfig) *Syncer {
+func NewSyncer(client *ogmigo.Client, handler *handler.BlockHandler, storage storage.Storage, clock *slottime.Clock, logger *zap.Logger, cfg config.ChainSyncConfig, ogmiosCfg config.OgmiosConfig) *Syncer {
 	return &Syncer{
 		ogmigoClient: client,
 		handler:      handler,
 		storage:      storage,
+		clock:        clock,
 		logger:       logger,
 		cfg:          cfg,
+		ogmiosCfg:    ogmiosCfg,
+		resumeCh:     make(chan struct{}, 1),
+		status:       syncStatus{state: model.SyncStateConnecting, startedAt: time.Now()},
 	}
 }
 
-// SetStartPoint sets a new point to start syncing from.
-func (s *Syncer) SetStartPoint(point model.Checkpoint) error {
+// SetStartPoint sets a new point to start syncing from. The audit entry is written with the change.
+func (s *Syncer) SetStartPoint(point model.Checkpoint, audit model.AuditEntry) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.logger.Info("setting new start point", zap.Uint64("slot", point.Slot), zap.String("hash", point.Hash))
-	if err := s.storage.ClearCheckpoints(); err != nil {
+	if err := s.storage.ClearCheckpoints(audit); err != nil {
 		return fmt.Errorf("could not clear checkpoints: %w", err)
 	}
 	s.startPoint = &point
@@ -59,16 +74,110 @@ func (s *Syncer) SetStartPoint(point model.Checkpoint) error {
 	return nil
 }
 
+// Pause stops consuming from Ogmios. The current position is kept in the checkpoints
+// and the paused state is persisted, so a restart stays paused until Resume is called. The audit
+// entry is written with the paused state.
+func (s *Syncer) Pause(audit model.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.storage.SetSetting(pausedSettingKey, "true", audit); err != nil {
+		return fmt.Errorf("could not persist paused state: %w", err)
+	}
+	if s.paused {
+		return nil
+	}
+	s.logger.Info("pausing chainsync")
+	s.paused = true
+	s.setState(model.SyncStatePaused)
+
+	if s.closer != nil {
+		s.closer.Close()
+	}
+
+	return nil
+}
+
+// Resume restarts consuming from Ogmios after a Pause. The audit entry is written with the
+// paused state.
+func (s *Syncer) Resume(audit model.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.storage.SetSetting(pausedSettingKey, "false", audit); err != nil {
+		return fmt.Errorf("could not persist paused state: %w", err)
+	}
+	if !s.paused {
+		return nil
+	}
+	s.logger.Info("resuming chainsync")
+	s.paused = false
+
+	select {
+	case s.resumeCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// waitWhilePaused blocks until the syncer is resumed or the context is cancelled.
+func (s *Syncer) waitWhilePaused(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		paused := s.paused
+		s.mu.Unlock()
+		if !paused {
+			return nil
+		}
+
+		s.setState(model.SyncStatePaused)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.resumeCh:
+		}
+	}
+}
+
+func (s *Syncer) isPaused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused
+}
+
 // Start begins the chain synchronization process.
 func (s *Syncer) Start(ctx context.Context) error {
+	defer s.setState(model.SyncStateStopped)
+
+	value, _, err := s.storage.GetSetting(pausedSettingKey)
+	if err != nil {
+		return fmt.Errorf("failed to load paused state: %w", err)
+	}
+	if value == "true" {
+		s.logger.Info("chainsync is paused, waiting for resume")
+		s.mu.Lock()
+		s.paused = true
+		s.mu.Unlock()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
+			if err := s.waitWhilePaused(ctx); err != nil {
+				return err
+			}
+			s.setState(model.SyncStateConnecting)
 			err := s.runSync(ctx)
+			if s.isPaused() {
+				// The connection was closed by Pause; wait for Resume on the next iteration.
+				continue
+			}
 			if err != nil {
 				s.logger.Error("chain sync error", zap.Error(err))
+				s.setState(model.SyncStateReconnecting)
 				// Exponential backoff or similar could be implemented here
 				time.Sleep(5 * time.Second)
 			}
@@ -81,6 +190,7 @@ func (s *Syncer) runSync(ctx context.Context) error {
 	var points []chainsync.Point
 	if s.startPoint != nil {
 		points = []chainsync.Point{chainsync.PointStruct{Slot: s.startPoint.Slot, ID: s.startPoint.Hash}.Point()}
+		s.setResumePoint(*s.startPoint)
 		s.startPoint = nil // Consume the start point
 	} else {
 		checkpoints, err := s.storage.GetLatestCheckpoints(s.cfg.MaxCheckpointsToKeep)
@@ -92,6 +202,7 @@ func (s *Syncer) runSync(ctx context.Context) error {
 			for _, cp := range checkpoints {
 				points = append(points, chainsync.PointStruct{Slot: cp.Slot, ID: cp.Hash}.Point())
 			}
+			s.setResumePoint(checkpoints[0])
 			s.logger.Info("resuming from latest checkpoints", zap.Any("points", points))
 		} else {
 			s.logger.Info("no checkpoints found, starting from origin")
@@ -129,6 +240,10 @@ func (s *Syncer) runSync(ctx context.Context) error {
 	}
 	s.mu.Lock()
 	s.closer = closer
+	if s.paused {
+		// Pause was called while the connection was being set up.
+		closer.Close()
+	}
 	s.mu.Unlock()
 
 	s.logger.Info("chainsync started")
@@ -148,7 +263,9 @@ func (s *Syncer) handleChainSyncResponse(response *chainsync.ResponsePraos) erro
 	switch response.Method {
 	case chainsync.FindIntersectionMethod:
 		findIntersectResult := response.MustFindIntersectResult()
+		s.recordTip(findIntersectResult.Tip)
 		if findIntersectResult.Intersection != nil {
+			s.setState(model.SyncStateSyncing)
 			// This is an informational message confirming the starting point of the sync.
 			s.logger.Info("intersection found",
 				zap.Any("intersection", findIntersectResult.Intersection),
@@ -162,12 +279,19 @@ func (s *Syncer) handleChainSyncResponse(response *chainsync.ResponsePraos) erro
 
 	case chainsync.NextBlockMethod:
 		nextBlockResult := response.MustNextBlockResult()
+		s.recordTip(nextBlockResult.Tip)
 		if nextBlockResult.Block != nil {
 			// Handle a new block by passing it to the block handler.
-			return s.handler.HandleRollForward(*nextBlockResult.Block, s.cfg.MaxCheckpointsToKeep)
+			if err := s.handler.HandleRollForward(*nextBlockResult.Block, s.cfg.MaxCheckpointsToKeep); err != nil {
+				return err
+			}
+			s.recordBlock(*nextBlockResult.Block)
 		} else {
 			// Handle a blockchain rollback.
-			return s.handler.HandleRollBackward(nextBlockResult.Point)
+			if err := s.handler.HandleRollBackward(nextBlockResult.Point); err != nil {
+				return err
+			}
+			s.recordRollback(nextBlockResult.Point)
 		}
 
 	default:
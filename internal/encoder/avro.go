@@ -0,0 +1,309 @@
+// internal/encoder/avro.go
+package encoder
+
+import (
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/schemaregistry"
+	"cardano-tx-sync/internal/utils"
+	_ "embed"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+	"github.com/hamba/avro/v2"
+)
+
+// avroMagicByte is the first byte of the Confluent wire format, followed by the 4-byte schema ID.
+const avroMagicByte = 0x0
+
+//go:embed schemas/txn_message.avsc
+var txnMessageSchemaJSON string
+
+func init() {
+	Register(Info{
+		Name:          "AVRO",
+		ContentType:   "application/vnd.apache.avro+binary",
+		SchemaVersion: "cardano.txsync.TxnMessage",
+		Description:   "Transaction as Avro in the Confluent Schema Registry wire format.",
+	}, func(options json.RawMessage) (Encoder, error) {
+		if err := decodeOptions(options, &struct{}{}); err != nil {
+			return nil, err
+		}
+		if avroEncoder == nil {
+			return nil, fmt.Errorf("avro encoder requires encoder.avro.schema_registry_url to be configured")
+		}
+		return avroEncoder, nil
+	})
+}
+
+// AvroEncoder encodes the message into Avro using the Confluent Schema Registry wire format.
+type AvroEncoder struct {
+	schema   avro.Schema
+	schemaID int
+}
+
+// NewAvroEncoder checks the TxnMessage schema against the latest version registered under the
+// subject, registers it and returns an encoder that stamps messages with the registered schema ID.
+func NewAvroEncoder(registry *schemaregistry.Client, subject string) (*AvroEncoder, error) {
+	schema, err := avro.Parse(txnMessageSchemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse avro schema: %w", err)
+	}
+
+	// Register the schema as written rather than its canonical form, which would drop the
+	// field defaults that the registry needs for its evolution checks.
+	compatible, err := registry.CheckCompatibility(subject, txnMessageSchemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check avro schema compatibility: %w", err)
+	}
+	if !compatible {
+		return nil, fmt.Errorf("avro schema is not compatible with the latest version of subject %s", subject)
+	}
+
+	id, err := registry.Register(subject, txnMessageSchemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register avro schema: %w", err)
+	}
+
+	return &AvroEncoder{schema: schema, schemaID: id}, nil
+}
+
+// Encode implements the Encoder interface.
+func (e *AvroEncoder) Encode(message model.TxnMessage) ([]byte, error) {
+	payload, err := avro.Marshal(e.schema, toAvroTxnMessage(message))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 5, 5+len(payload))
+	out[0] = avroMagicByte
+	binary.BigEndian.PutUint32(out[1:5], uint32(e.schemaID))
+	return append(out, payload...), nil
+}
+
+type avroTxnMessage struct {
+	Tx          avroTx      `avro:"tx"`
+	Block       avroBlock   `avro:"block"`
+	Network     avroNetwork `avro:"network"`
+	Invalidated bool        `avro:"invalidated"`
+	TxIndex     int         `avro:"tx_index"`
+}
+
+type avroTx struct {
+	ID                       string            `avro:"id"`
+	Spends                   string            `avro:"spends"`
+	Inputs                   []avroOutputRef   `avro:"inputs"`
+	References               []avroOutputRef   `avro:"references"`
+	Collaterals              []avroOutputRef   `avro:"collaterals"`
+	Outputs                  []avroTxOutput    `avro:"outputs"`
+	CollateralReturn         *avroTxOutput     `avro:"collateral_return"`
+	TotalCollateral          *int64            `avro:"total_collateral"`
+	Fee                      int64             `avro:"fee"`
+	Mint                     map[string]string `avro:"mint"`
+	Withdrawals              map[string]int64  `avro:"withdrawals"`
+	InvalidBefore            *int64            `avro:"invalid_before"`
+	InvalidAfter             *int64            `avro:"invalid_after"`
+	ValidFrom                *time.Time        `avro:"valid_from"`
+	ValidUntil               *time.Time        `avro:"valid_until"`
+	Certificates             []string          `avro:"certificates"`
+	RequiredExtraSignatories []string          `avro:"required_extra_signatories"`
+	ScriptIntegrityHash      *string           `avro:"script_integrity_hash"`
+	Metadata                 *string           `avro:"metadata"`
+	Datums                   map[string]string `avro:"datums"`
+	Redeemers                *string           `avro:"redeemers"`
+	Signatures               map[string]string `avro:"signatures"`
+	Proposals                *string           `avro:"proposals"`
+	Votes                    *string           `avro:"votes"`
+	CBOR                     *string           `avro:"cbor"`
+}
+
+type avroOutputRef struct {
+	TransactionID string `avro:"transaction_id"`
+	Index         int    `avro:"index"`
+}
+
+type avroTxOutput struct {
+	Address   string            `avro:"address"`
+	Lovelace  int64             `avro:"lovelace"`
+	Assets    map[string]string `avro:"assets"`
+	Datum     *string           `avro:"datum"`
+	DatumHash *string           `avro:"datum_hash"`
+	Script    *string           `avro:"script"`
+}
+
+type avroBlock struct {
+	Hash      string    `avro:"hash"`
+	Slot      int64     `avro:"slot"`
+	Era       string    `avro:"era"`
+	Time      time.Time `avro:"time"`
+	Epoch     int64     `avro:"epoch"`
+	EpochSlot int64     `avro:"epoch_slot"`
+	Height    int64     `avro:"height"`
+	Issuer    *string   `avro:"issuer_pool_id"`
+	Size      int64     `avro:"size"`
+	TxCount   int       `avro:"tx_count"`
+}
+
+type avroNetwork struct {
+	Name  string `avro:"name"`
+	Magic int64  `avro:"magic"`
+}
+
+func toAvroTxnMessage(message model.TxnMessage) *avroTxnMessage {
+	tx := message.Tx
+
+	outputs := make([]avroTxOutput, len(tx.Outputs))
+	for i, out := range tx.Outputs {
+		outputs[i] = toAvroTxOutput(out)
+	}
+
+	var collateralReturn *avroTxOutput
+	if tx.CollateralReturn != nil {
+		out := toAvroTxOutput(*tx.CollateralReturn)
+		collateralReturn = &out
+	}
+
+	var totalCollateral *int64
+	if tx.TotalCollateral != nil {
+		lovelace, _ := splitAvroValue(*tx.TotalCollateral)
+		totalCollateral = &lovelace
+	}
+
+	fee, _ := splitAvroValue(tx.Fee)
+	_, mint := splitAvroValue(tx.Mint)
+
+	withdrawals := make(map[string]int64, len(tx.Withdrawals))
+	for rewardAddress, value := range tx.Withdrawals {
+		withdrawals[rewardAddress], _ = splitAvroValue(value)
+	}
+
+	certificates := make([]string, len(tx.Certificates))
+	for i, cert := range tx.Certificates {
+		certificates[i] = string(cert)
+	}
+
+	signatures := make(map[string]string, len(tx.Signatories))
+	for _, signatory := range tx.Signatories {
+		signatures[signatory.Key] = signatory.Signature
+	}
+
+	datums := make(map[string]string, len(tx.Datums))
+	for hash, datum := range tx.Datums {
+		datums[hash] = datum
+	}
+
+	spends := tx.Spends
+	if spends == "" {
+		spends = "inputs"
+	}
+
+	return &avroTxnMessage{
+		Tx: avroTx{
+			ID:                       tx.ID,
+			Spends:                   spends,
+			Inputs:                   toAvroOutputRefs(tx.Inputs),
+			References:               toAvroOutputRefs(tx.References),
+			Collaterals:              toAvroOutputRefs(tx.Collaterals),
+			Outputs:                  outputs,
+			CollateralReturn:         collateralReturn,
+			TotalCollateral:          totalCollateral,
+			Fee:                      fee,
+			Mint:                     mint,
+			Withdrawals:              withdrawals,
+			InvalidBefore:            optionalSlot(tx.ValidityInterval.InvalidBefore),
+			InvalidAfter:             optionalSlot(tx.ValidityInterval.InvalidAfter),
+			ValidFrom:                message.ValidFrom,
+			ValidUntil:               message.ValidUntil,
+			Certificates:             certificates,
+			RequiredExtraSignatories: append([]string{}, tx.RequiredExtraSignatories...),
+			ScriptIntegrityHash:      optionalString(tx.ScriptIntegrityHash),
+			Metadata:                 optionalJSON(tx.Metadata),
+			Datums:                   datums,
+			Redeemers:                optionalJSON(tx.Redeemers),
+			Signatures:               signatures,
+			Proposals:                optionalJSON(tx.Proposals),
+			Votes:                    optionalJSON(tx.Votes),
+			CBOR:                     optionalString(tx.CBOR),
+		},
+		Block: avroBlock{
+			Hash:      message.Block.Hash,
+			Slot:      int64(message.Block.Slot),
+			Era:       message.Block.Era,
+			Time:      message.Block.Time,
+			Epoch:     int64(message.Block.Epoch),
+			EpochSlot: int64(message.Block.EpochSlot),
+			Height:    int64(message.Block.Height),
+			Issuer:    optionalString(message.Block.IssuerPoolID),
+			Size:      message.Block.Size,
+			TxCount:   message.Block.TxCount,
+		},
+		Network: avroNetwork{
+			Name:  message.Network.Name,
+			Magic: int64(message.Network.Magic),
+		},
+		Invalidated: message.Invalidated,
+		TxIndex:     message.TxIndex,
+	}
+}
+
+func toAvroTxOutput(out chainsync.TxOut) avroTxOutput {
+	lovelace, assets := splitAvroValue(out.Value)
+	return avroTxOutput{
+		Address:   out.Address,
+		Lovelace:  lovelace,
+		Assets:    assets,
+		Datum:     optionalString(out.Datum),
+		DatumHash: optionalString(out.DatumHash),
+		Script:    optionalJSON(out.Script),
+	}
+}
+
+func toAvroOutputRefs(ins []chainsync.TxIn) []avroOutputRef {
+	refs := make([]avroOutputRef, len(ins))
+	for i, in := range ins {
+		refs[i] = avroOutputRef{TransactionID: in.Transaction.ID, Index: in.Index}
+	}
+	return refs
+}
+
+// splitAvroValue separates lovelace from native assets, whose quantities are kept as decimal strings.
+func splitAvroValue(value shared.Value) (int64, map[string]string) {
+	lovelace := int64(0)
+	assets := make(map[string]string)
+	for policyId, items := range value {
+		for assetName, qty := range items {
+			if policyId == shared.AdaPolicy && assetName == shared.AdaAsset {
+				lovelace = qty.Int64()
+			} else {
+				assets[utils.GetAsset(policyId, assetName)] = qty.String()
+			}
+		}
+	}
+	return lovelace, assets
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalJSON(raw json.RawMessage) *string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	s := string(raw)
+	return &s
+}
+
+func optionalSlot(slot uint64) *int64 {
+	if slot == 0 {
+		return nil
+	}
+	v := int64(slot)
+	return &v
+}
@@ -0,0 +1,138 @@
+// internal/encoder/avro_test.go
+package encoder
+
+import (
+	"cardano-tx-sync/config"
+	"cardano-tx-sync/internal/schemaregistry"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hamba/avro/v2"
+)
+
+const testSubject = "cardano.txsync.TxnMessage"
+
+// fakeRegistry is a schema registry holding one subject, whose latest version is compatible or not
+// with the registered schemas.
+type fakeRegistry struct {
+	compatible bool
+	id         int
+	// registered is the schema of the last registration.
+	registered string
+}
+
+func (f *fakeRegistry) start(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Schema string `json:"schema"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/compatibility/subjects/" + testSubject + "/versions/latest":
+			json.NewEncoder(w).Encode(map[string]bool{"is_compatible": f.compatible})
+		case "/subjects/" + testSubject + "/versions":
+			if !f.compatible {
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(map[string]interface{}{"error_code": 409, "message": "incompatible"})
+				return
+			}
+			f.registered = req.Schema
+			json.NewEncoder(w).Encode(map[string]int{"id": f.id})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAvroEncoderWireFormat(t *testing.T) {
+	registry := &fakeRegistry{compatible: true, id: 0x01020304}
+	server := registry.start(t)
+
+	enc, err := NewAvroEncoder(schemaregistry.NewClient(server.URL, "", "", 5*time.Second), testSubject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registry.registered != txnMessageSchemaJSON {
+		t.Error("the schema is not registered as written")
+	}
+
+	message := testMessage(t)
+	raw, err := enc.Encode(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) < 5 || raw[0] != avroMagicByte {
+		t.Fatalf("message does not start with the magic byte: % x", raw[:min(len(raw), 5)])
+	}
+	if id := binary.BigEndian.Uint32(raw[1:5]); id != 0x01020304 {
+		t.Errorf("schema ID = %#x, want 0x01020304", id)
+	}
+
+	var decoded avroTxnMessage
+	if err := avro.Unmarshal(avro.MustParse(txnMessageSchemaJSON), raw[5:], &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Tx.ID != message.Tx.ID || decoded.Block.Slot != int64(message.Block.Slot) || decoded.TxIndex != message.TxIndex {
+		t.Errorf("decoded message is %s at slot %d index %d, want %s at slot %d index %d",
+			decoded.Tx.ID, decoded.Block.Slot, decoded.TxIndex, message.Tx.ID, message.Block.Slot, message.TxIndex)
+	}
+}
+
+func TestAvroEncoderIncompatibleSchema(t *testing.T) {
+	registry := &fakeRegistry{compatible: false, id: 1}
+	server := registry.start(t)
+
+	if _, err := NewAvroEncoder(schemaregistry.NewClient(server.URL, "", "", 5*time.Second), testSubject); err == nil {
+		t.Fatal("an incompatible schema was accepted")
+	}
+	if registry.registered != "" {
+		t.Error("an incompatible schema was registered")
+	}
+}
+
+// TestConfigureAvro checks that startup fails when the schema is incompatible, and that the AVRO
+// encoder is only available once a registry is configured.
+func TestConfigureAvro(t *testing.T) {
+	t.Cleanup(func() { avroEncoder = nil })
+	avroConfig := func(url string) config.EncoderConfig {
+		return config.EncoderConfig{Avro: config.AvroConfig{SchemaRegistryURL: url, Subject: testSubject, Timeout: 5 * time.Second}}
+	}
+
+	if err := Configure(config.EncoderConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New("AVRO", nil); err == nil {
+		t.Error("AVRO is available without a schema registry")
+	}
+
+	incompatible := (&fakeRegistry{compatible: false}).start(t)
+	if err := Configure(avroConfig(incompatible.URL)); err == nil {
+		t.Error("startup succeeded with an incompatible schema")
+	}
+
+	compatible := (&fakeRegistry{compatible: true, id: 9}).start(t)
+	if err := Configure(avroConfig(compatible.URL)); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := New("AVRO", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := enc.Encode(testMessage(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := binary.BigEndian.Uint32(raw[1:5]); id != 9 {
+		t.Errorf("schema ID = %d, want 9", id)
+	}
+}
@@ -0,0 +1,134 @@
+// internal/encoder/balance.go
+package encoder
+
+import (
+	"cardano-tx-sync/internal/address"
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/utils"
+	"encoding/json"
+	"sort"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+)
+
+func init() {
+	Register(Info{
+		Name:           "BALANCE",
+		ContentType:    "application/json",
+		SchemaVersion:  "1",
+		Description:    "Net value received minus spent by the watched address or stake credential, with counterpart addresses.",
+		PerMapping:     true,
+		ResolvesInputs: true,
+	}, noOptions(&BalanceEncoder{}))
+}
+
+// BalanceEncoder publishes the balance change of the entity watched by an address or stake
+// credential mapping: the value of its outputs minus the value of the tracked outputs it spends.
+// Other mappings, including wildcards, are skipped, as are messages with unresolved inputs.
+type BalanceEncoder struct{}
+
+type balanceMessage struct {
+	TxID        string             `json:"tx_id"`
+	Block       model.BlockDetails `json:"block"`
+	Network     model.Network      `json:"network"`
+	Invalidated bool               `json:"invalidated"`
+	Watched     balanceWatched     `json:"watched"`
+	Delta       balanceDelta       `json:"delta"`
+	// Counterparts are the other addresses of the outputs and resolved inputs.
+	Counterparts []string `json:"counterparts"`
+}
+
+type balanceWatched struct {
+	Type model.MappingType `json:"type"`
+	Key  string            `json:"key"`
+}
+
+// balanceDelta holds signed quantities as decimal strings. Assets with no net change are omitted.
+type balanceDelta struct {
+	Lovelace string                       `json:"lovelace"`
+	Assets   map[string]map[string]string `json:"assets"`
+}
+
+// Encode implements the Encoder interface. Without a mapping there is nothing to watch.
+func (e *BalanceEncoder) Encode(message model.TxnMessage) ([]byte, error) {
+	return nil, ErrSkip
+}
+
+// EncodeForMapping implements the MappingEncoder interface.
+func (e *BalanceEncoder) EncodeForMapping(message model.TxnMessage, mapping model.Mapping) ([]byte, error) {
+	if mapping.Key == "*" || (mapping.Type != model.MappingTypeAddress && mapping.Type != model.MappingTypeStakeCredential) {
+		return nil, ErrSkip
+	}
+	// Spent outputs of the entity may be missing, which would make its change look received.
+	if message.UnresolvedInputs {
+		return nil, ErrSkip
+	}
+	watched := func(addr string) bool {
+		if mapping.Type == model.MappingTypeAddress {
+			return addr == mapping.Key
+		}
+		stakeHash, ok := address.StakeCredentialHash(addr)
+		return ok && stakeHash == mapping.Key
+	}
+
+	involved := false
+	received, spent := shared.Value{}, shared.Value{}
+	counterparts := make(map[string]struct{})
+	for _, out := range utils.ProducedOutputs(message.Tx) {
+		if watched(out.Address) {
+			involved = true
+			received = shared.Add(received, out.Value)
+		} else {
+			counterparts[out.Address] = struct{}{}
+		}
+	}
+	for _, in := range message.ResolvedInputs {
+		if watched(in.Address) {
+			involved = true
+			spent = shared.Add(spent, in.Value)
+		} else {
+			counterparts[in.Address] = struct{}{}
+		}
+	}
+	if !involved {
+		return nil, ErrSkip
+	}
+
+	sortedCounterparts := make([]string, 0, len(counterparts))
+	for addr := range counterparts {
+		sortedCounterparts = append(sortedCounterparts, addr)
+	}
+	sort.Strings(sortedCounterparts)
+
+	return json.Marshal(balanceMessage{
+		TxID:         message.Tx.ID,
+		Block:        message.Block,
+		Network:      message.Network,
+		Invalidated:  message.Invalidated,
+		Watched:      balanceWatched{Type: mapping.Type, Key: mapping.Key},
+		Delta:        newBalanceDelta(shared.Subtract(received, spent)),
+		Counterparts: sortedCounterparts,
+	})
+}
+
+func newBalanceDelta(value shared.Value) balanceDelta {
+	delta := balanceDelta{
+		Lovelace: value.AdaLovelace().String(),
+		Assets:   make(map[string]map[string]string),
+	}
+	for policyID, assets := range value {
+		if policyID == shared.AdaPolicy {
+			continue
+		}
+		for assetName, qty := range assets {
+			if qty.BigInt().Sign() == 0 {
+				continue
+			}
+			if _, ok := delta.Assets[policyID]; !ok {
+				delta.Assets[policyID] = make(map[string]string)
+			}
+			delta.Assets[policyID][assetName] = qty.String()
+		}
+	}
+	return delta
+}
@@ -0,0 +1,24 @@
+// internal/encoder/balance_test.go
+package encoder
+
+import (
+	"cardano-tx-sync/internal/model"
+	"testing"
+)
+
+func TestBalanceSkipsUnresolvedInputs(t *testing.T) {
+	message := extremeMessage(t)
+	mapping := model.Mapping{Type: model.MappingTypeAddress, Key: message.Tx.Outputs[0].Address}
+	enc, err := New("BALANCE", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := enc.(MappingEncoder).EncodeForMapping(message, mapping); err != nil {
+		t.Fatal(err)
+	}
+
+	message.UnresolvedInputs = true
+	if _, err := enc.(MappingEncoder).EncodeForMapping(message, mapping); err != ErrSkip {
+		t.Errorf("error = %v, want the message skipped", err)
+	}
+}
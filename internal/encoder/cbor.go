@@ -0,0 +1,65 @@
+// internal/encoder/cbor.go
+package encoder
+
+import (
+	"cardano-tx-sync/internal/model"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// errNoTransactionCBOR is returned when Ogmios did not include the transaction CBOR.
+var errNoTransactionCBOR = errors.New("transaction CBOR is not available, start Ogmios with --include-transaction-cbor")
+
+func init() {
+	Register(Info{
+		Name:          "CBOR",
+		ContentType:   "application/cbor",
+		SchemaVersion: "1",
+		Description:   "Original transaction CBOR in a CBOR envelope with block hash, slot, block time and tx index.",
+	}, noOptions(&CBOREncoder{}))
+}
+
+// CBOREncoder publishes the original transaction CBOR, byte for byte, in a small CBOR envelope.
+type CBOREncoder struct{}
+
+// cborEnvelope is the published message. The block time is in POSIX seconds. The transaction is carried as a byte string so that
+// consumers can recompute the transaction hash and verify witnesses over the exact bytes.
+type cborEnvelope struct {
+	TxID         string `cbor:"tx_id"`
+	BlockHash    []byte `cbor:"block_hash"`
+	Slot         uint64 `cbor:"slot"`
+	BlockTime    int64  `cbor:"block_time"`
+	BlockHeight  uint64 `cbor:"block_height"`
+	TxIndex      uint64 `cbor:"tx_index"`
+	NetworkMagic uint32 `cbor:"network_magic"`
+	Cbor         []byte `cbor:"cbor"`
+}
+
+// Encode implements the Encoder interface.
+func (e *CBOREncoder) Encode(message model.TxnMessage) ([]byte, error) {
+	if message.Tx.CBOR == "" {
+		return nil, errNoTransactionCBOR
+	}
+	txBytes, err := hex.DecodeString(message.Tx.CBOR)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction CBOR: %w", err)
+	}
+	blockHash, err := hex.DecodeString(message.Block.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block hash: %w", err)
+	}
+
+	return cbor.Marshal(cborEnvelope{
+		TxID:         message.Tx.ID,
+		BlockHash:    blockHash,
+		Slot:         message.Block.Slot,
+		BlockTime:    message.Block.Time.Unix(),
+		BlockHeight:  message.Block.Height,
+		TxIndex:      uint64(message.TxIndex),
+		NetworkMagic: message.Network.Magic,
+		Cbor:         txBytes,
+	})
+}
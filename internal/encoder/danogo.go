@@ -13,28 +13,104 @@ import (
 	"github.com/gogo/protobuf/types"
 )
 
-// DanogoEncoder encodes the message into the custom CardanoTransaction protobuf format.
-type DanogoEncoder struct{}
+func init() {
+	Register(Info{
+		Name:          "DANOGO",
+		ContentType:   "application/json",
+		SchemaVersion: "cardano.txsync.v1",
+		Description:   "CardanoTransactionEvent from proto/event.proto, as plain JSON with snake_case field names.",
+	}, func(options json.RawMessage) (Encoder, error) {
+		opts, err := danogoTransformOptions(options)
+		if err != nil {
+			return nil, err
+		}
+		return &DanogoEncoder{Options: opts}, nil
+	})
+}
+
+// danogoOptions are the per-mapping options of the DANOGO encoders. Unset options fall back
+// to the encoder configuration.
+type danogoOptions struct {
+	LegacyInt64Quantities *bool `json:"legacy_int64_quantities"`
+	DecodePlutusData      bool  `json:"decode_plutus_data"`
+}
+
+func danogoTransformOptions(options json.RawMessage) (transformer.Options, error) {
+	var o danogoOptions
+	if err := decodeOptions(options, &o); err != nil {
+		return transformer.Options{}, err
+	}
+	opts := transformOptions
+	opts.LegacyInt64Quantities = legacyQuantities(o.LegacyInt64Quantities)
+	opts.DecodePlutusData = o.DecodePlutusData
+	return opts, nil
+}
+
+// DanogoEncoder encodes the message into the CardanoTransactionEvent defined in
+// proto/event.proto, marshalled with encoding/json as it always has been. New consumers should
+// prefer DANOGO_PROTOBUF or DANOGO_JSON, which follow the protobuf encodings.
+type DanogoEncoder struct {
+	Options transformer.Options
+}
 
 // Encode implements the Encoder interface.
 func (e *DanogoEncoder) Encode(message model.TxnMessage) ([]byte, error) {
-	// Transform the Ogmigo transaction model to our target protobuf model.
-	pbTx, err := transformer.ToCardanoTransaction(message)
+	event, err := toDanogoEvent(message, e.Options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to transform transaction for danogo encoder: %w", err)
+		return nil, err
+	}
+	raw, err := json.Marshal(event)
+	if err != nil || len(event.GetTransaction().GetBody().GetWithdrawals()) == 0 {
+		return raw, err
+	}
+	return setLegacyWithdrawals(raw, event.Transaction.Body.Withdrawals)
+}
+
+// setLegacyWithdrawals writes legacy withdrawals the way earlier releases did, as
+// {"<reward address>": {"ada.lovelace": <quantity>}}, rather than as LegacyAmounts messages.
+func setLegacyWithdrawals(raw []byte, withdrawals map[string]*pb.LegacyAmounts) ([]byte, error) {
+	legacy := make(map[string]map[string]int64, len(withdrawals))
+	for account, amounts := range withdrawals {
+		legacy[account] = amounts.GetAssets()
 	}
 
-	// Marshal the protobuf message into its binary format.
-	// return proto.Marshal(MapMsgType2Event(42, pbTx))
+	event, err := parseJSONObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := parseJSONObject(event.get("transaction"))
+	if err != nil {
+		return nil, err
+	}
+	body, err := parseJSONObject(tx.get("body"))
+	if err != nil {
+		return nil, err
+	}
+	if err := body.set("withdrawals", legacy); err != nil {
+		return nil, err
+	}
+	if err := tx.set("body", body); err != nil {
+		return nil, err
+	}
+	if err := event.set("transaction", tx); err != nil {
+		return nil, err
+	}
+	return json.Marshal(event)
+}
 
-	return json.Marshal(MapMsgType2Event(42, pbTx))
+// toDanogoEvent transforms the Ogmigo transaction model into the protobuf event shared by
+// the DANOGO encoders.
+func toDanogoEvent(message model.TxnMessage, opts transformer.Options) (*pb.CardanoTransactionEvent, error) {
+	pbTx, err := transformer.ToCardanoTransaction(message, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform transaction for danogo encoder: %w", err)
+	}
+	return MapMsgType2Event(int32(message.Network.Magic), pbTx, message.Block.Time), nil
 }
 
-// func MapMsgType2Event(networkMagic int32, tx *pb.CardanoTransaction) proto.Message {
-func MapMsgType2Event(networkMagic int32, tx *pb.CardanoTransaction) interface{} {
-	// get current timestamp
-	now := time.Now()
-	ts, _ := types.TimestampProto(now)
+// MapMsgType2Event wraps a transaction in an event timestamped with the time of its block.
+func MapMsgType2Event(networkMagic int32, tx *pb.CardanoTransaction, blockTime time.Time) *pb.CardanoTransactionEvent {
+	ts, _ := types.TimestampProto(blockTime)
 	return &pb.CardanoTransactionEvent{
 		NetworkMagic: networkMagic,
 		Transaction:  tx,
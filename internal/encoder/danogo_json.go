@@ -0,0 +1,48 @@
+// internal/encoder/danogo_json.go
+package encoder
+
+import (
+	"bytes"
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/transformer"
+	"encoding/json"
+
+	"github.com/gogo/protobuf/jsonpb"
+)
+
+func init() {
+	Register(Info{
+		Name:          "DANOGO_JSON",
+		ContentType:   "application/json",
+		SchemaVersion: "cardano.txsync.v1",
+		Description:   "CardanoTransactionEvent from proto/event.proto, in the protobuf JSON mapping.",
+	}, func(options json.RawMessage) (Encoder, error) {
+		opts, err := danogoTransformOptions(options)
+		if err != nil {
+			return nil, err
+		}
+		return &DanogoJSONEncoder{Options: opts}, nil
+	})
+}
+
+// DanogoJSONEncoder encodes the message into the CardanoTransactionEvent protobuf message
+// using the canonical protobuf JSON mapping, with the field names from proto/event.proto.
+type DanogoJSONEncoder struct {
+	Options transformer.Options
+}
+
+var danogoJSONMarshaler = jsonpb.Marshaler{OrigName: true}
+
+// Encode implements the Encoder interface.
+func (e *DanogoJSONEncoder) Encode(message model.TxnMessage) ([]byte, error) {
+	event, err := toDanogoEvent(message, e.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := danogoJSONMarshaler.Marshal(&buf, event); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,42 @@
+// internal/encoder/danogo_protobuf.go
+package encoder
+
+import (
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/transformer"
+	"encoding/json"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+func init() {
+	Register(Info{
+		Name:          "DANOGO_PROTOBUF",
+		ContentType:   "application/x-protobuf",
+		SchemaVersion: "cardano.txsync.v1",
+		Description:   "CardanoTransactionEvent from proto/event.proto, in protobuf binary wire format.",
+	}, func(options json.RawMessage) (Encoder, error) {
+		opts, err := danogoTransformOptions(options)
+		if err != nil {
+			return nil, err
+		}
+		return &DanogoProtobufEncoder{Options: opts}, nil
+	})
+}
+
+// DanogoProtobufEncoder encodes the message into the CardanoTransactionEvent protobuf binary
+// format defined in proto/event.proto.
+type DanogoProtobufEncoder struct {
+	Options transformer.Options
+}
+
+// Encode implements the Encoder interface.
+func (e *DanogoProtobufEncoder) Encode(message model.TxnMessage) ([]byte, error) {
+	event, err := toDanogoEvent(message, e.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Marshal the protobuf message into its binary format.
+	return proto.Marshal(event)
+}
@@ -0,0 +1,99 @@
+// internal/encoder/danogo_test.go
+package encoder
+
+import (
+	"bytes"
+	"cardano-tx-sync/internal/pb"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+)
+
+func marshalEventJSON(t *testing.T, event *pb.CardanoTransactionEvent) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	m := jsonpb.Marshaler{OrigName: true, Indent: "  "}
+	if err := m.Marshal(&buf, event); err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// TestDanogoProtobufRoundTrip checks that the binary encoding decodes back to the same event,
+// whose content is pinned by a golden file.
+func TestDanogoProtobufRoundTrip(t *testing.T) {
+	message := testMessage(t)
+	raw := mustEncode(t, "DANOGO_PROTOBUF", "", message)
+
+	var event pb.CardanoTransactionEvent
+	if err := proto.Unmarshal(raw, &event); err != nil {
+		t.Fatal(err)
+	}
+	want, err := toDanogoEvent(message, transformOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(want, &event) {
+		t.Errorf("decoded event differs from the encoded one:\ngot:  %v\nwant: %v", &event, want)
+	}
+	golden(t, "danogo_event.json", marshalEventJSON(t, &event))
+
+	if *update {
+		if err := os.WriteFile(filepath.Join("testdata", "danogo_event.pb"), raw, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestDanogoProtobufWireCompatibility decodes an event encoded by an earlier build. Renumbering or
+// retyping a field of proto/event.proto breaks it, as it would break existing consumers.
+func TestDanogoProtobufWireCompatibility(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "danogo_event.pb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event pb.CardanoTransactionEvent
+	if err := proto.Unmarshal(raw, &event); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "danogo_event.json", marshalEventJSON(t, &event))
+}
+
+// TestDanogoJSON checks that DANOGO_JSON follows the protobuf JSON mapping: it parses back into
+// the same event.
+func TestDanogoJSON(t *testing.T) {
+	message := testMessage(t)
+	raw := mustEncode(t, "DANOGO_JSON", "", message)
+
+	var event pb.CardanoTransactionEvent
+	if err := jsonpb.Unmarshal(bytes.NewReader(raw), &event); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "danogo_event.json", marshalEventJSON(t, &event))
+}
+
+// TestDanogoPlainJSON pins the plain JSON format of DANOGO.
+func TestDanogoPlainJSON(t *testing.T) {
+	golden(t, "danogo.json", append(mustEncode(t, "DANOGO", "", testMessage(t)), '\n'))
+}
+
+// TestDanogoLegacyJSON checks that DANOGO with legacy quantities writes what existing consumers
+// parse: the messages of the release before lossless quantities.
+func TestDanogoLegacyJSON(t *testing.T) {
+	checkLegacyGolden(t, "danogo_legacy.json", mustEncode(t, "DANOGO", legacyOption, testMessage(t)),
+		// Added since.
+		"transaction.block_time", "transaction.epoch", "transaction.epoch_slot",
+		"transaction.block_height", "transaction.block_size", "transaction.block_tx_count",
+		"transaction.tx_index", "transaction.body.outputs.*.address_details",
+		"transaction.body.valid_from", "transaction.body.valid_until",
+		// The timestamp was the time of encoding and the network magic was always 42. They are
+		// now the block time and the configured network's magic.
+		"timestamp", "network_magic",
+		// The validity interval bounds were swapped.
+		"transaction.body.validity_interval_start", "transaction.body.validity_interval_end",
+	)
+}
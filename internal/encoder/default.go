@@ -2,14 +2,104 @@
 package encoder
 
 import (
+	"cardano-tx-sync/internal/address"
 	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/plutus"
 	"encoding/json"
 )
 
-// DefaultEncoder encodes the message as a full JSON object.
-type DefaultEncoder struct{}
+func init() {
+	Register(Info{
+		Name:          "DEFAULT",
+		ContentType:   "application/json",
+		SchemaVersion: "1",
+		Description:   "Full transaction as JSON, in the Ogmios representation, with block details.",
+	}, func(options json.RawMessage) (Encoder, error) {
+		var o defaultOptions
+		if err := decodeOptions(options, &o); err != nil {
+			return nil, err
+		}
+		return &DefaultEncoder{
+			DecodePlutusData:      o.DecodePlutusData,
+			LegacyInt64Quantities: legacyQuantities(o.LegacyInt64Quantities),
+		}, nil
+	})
+}
+
+// defaultOptions are the per-mapping options of the DEFAULT encoder.
+type defaultOptions struct {
+	DecodePlutusData      bool  `json:"decode_plutus_data"`
+	LegacyInt64Quantities *bool `json:"legacy_int64_quantities"`
+}
+
+// DefaultEncoder encodes the message as a full JSON object, with the address of each output decoded.
+type DefaultEncoder struct {
+	// DecodePlutusData adds a plutusData object with the datums, redeemers and inline output
+	// datums decoded to detailed-schema JSON.
+	DecodePlutusData bool
+	// LegacyInt64Quantities writes native asset quantities as JSON numbers, as Ogmios does, instead
+	// of decimal strings.
+	LegacyInt64Quantities bool
+}
 
-// Encode implements the Encoder interface.
+// Encode implements the Encoder interface. The message is the transaction message with the address
+// of each output decoded and, unless legacy quantities are enabled, the native asset quantities
+// written as decimal strings. The fields keep the order of the marshalled transaction message.
 func (e *DefaultEncoder) Encode(message model.TxnMessage) ([]byte, error) {
-	return json.Marshal(message)
+	legacy := e.LegacyInt64Quantities
+	tx, err := marshalObject(message.Tx)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if message.Tx.TotalCollateral != nil {
+		fields["totalCollateral"] = jsonValue{value: *message.Tx.TotalCollateral, legacy: legacy}
+	}
+	if message.Tx.CollateralReturn != nil {
+		fields["collateralReturn"] = newJSONTxOut(*message.Tx.CollateralReturn, legacy)
+	}
+	if len(message.Tx.Outputs) > 0 {
+		outputs := make([]jsonTxOut, 0, len(message.Tx.Outputs))
+		for _, output := range message.Tx.Outputs {
+			out := newJSONTxOut(output, legacy)
+			out.AddressDetails, _ = address.Describe(output.Address)
+			outputs = append(outputs, out)
+		}
+		fields["outputs"] = outputs
+	}
+	if withdrawals := newJSONWithdrawals(message.Tx.Withdrawals, legacy); withdrawals != nil {
+		fields["withdrawals"] = withdrawals
+	}
+	if mint := newJSONValue(message.Tx.Mint, legacy); mint != nil {
+		fields["mint"] = mint
+	}
+	// Only fields the transaction has are replaced, so the order of the map does not matter.
+	for name, value := range fields {
+		if err := tx.set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	msg, err := marshalObject(message)
+	if err != nil {
+		return nil, err
+	}
+	if err := msg.set("tx", tx); err != nil {
+		return nil, err
+	}
+	if e.DecodePlutusData {
+		if err := msg.set("plutusData", plutus.DecodeTx(message.Tx)); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(msg)
+}
+
+// marshalObject marshals a struct into a jsonObject.
+func marshalObject(v interface{}) (*jsonObject, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return parseJSONObject(raw)
 }
@@ -2,26 +2,52 @@
 package encoder
 
 import (
+	"cardano-tx-sync/config"
 	"cardano-tx-sync/internal/model"
-	"fmt"
-	"strings"
+	"cardano-tx-sync/internal/schemaregistry"
+	"cardano-tx-sync/internal/transformer"
+	"errors"
 )
 
-// Encoder defines the interface for message encoders.
+// ErrSkip is returned by encoders that have nothing to publish for a transaction, such as the NFT
+// encoder for a transaction without NFT metadata. It is not an encoding failure.
+var ErrSkip = errors.New("nothing to publish")
+
+// Encoder defines the interface for message encoders. An encoder is built once per mapping and
+// reused for every transaction the mapping matches, from several goroutines at once.
 type Encoder interface {
 	Encode(message model.TxnMessage) ([]byte, error)
 }
 
-// GetEncoder returns an encoder instance by name.
-func GetEncoder(name string) (Encoder, error) {
-	switch strings.ToUpper(name) {
-	case "DEFAULT":
-		return &DefaultEncoder{}, nil
-	case "SIMPLE":
-		return &SimpleEncoder{}, nil
-	case "DANOGO":
-		return &DanogoEncoder{}, nil
-	default:
-		return nil, fmt.Errorf("unknown encoder: %s", name)
+// MappingEncoder is implemented by encoders whose output depends on the mapping that matched the
+// transaction. Such encoders are registered with Info.PerMapping set.
+type MappingEncoder interface {
+	Encoder
+	EncodeForMapping(message model.TxnMessage, mapping model.Mapping) ([]byte, error)
+}
+
+var (
+	// transformOptions are the transformer options used by the DANOGO encoders.
+	transformOptions transformer.Options
+	// avroEncoder is set when a schema registry is configured.
+	avroEncoder *AvroEncoder
+)
+
+// Configure applies the encoder configuration. It must be called before any encoder is used.
+// When a schema registry is configured, the Avro schema is checked and registered.
+func Configure(cfg config.EncoderConfig) error {
+	transformOptions = transformer.Options{
+		LegacyInt64Quantities: cfg.LegacyInt64Quantities,
+	}
+
+	if cfg.Avro.SchemaRegistryURL != "" {
+		registry := schemaregistry.NewClient(cfg.Avro.SchemaRegistryURL, cfg.Avro.Username, cfg.Avro.Password, cfg.Avro.Timeout)
+		enc, err := NewAvroEncoder(registry, cfg.Avro.Subject)
+		if err != nil {
+			return err
+		}
+		avroEncoder = enc
 	}
+
+	return nil
 }
@@ -0,0 +1,78 @@
+// internal/encoder/encoder_test.go
+package encoder
+
+import (
+	"bytes"
+	"cardano-tx-sync/internal/model"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// testMessage returns the message of the transaction in testdata/tx.json, in a fixed block.
+func testMessage(t *testing.T) model.TxnMessage {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", "tx.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tx chainsync.Tx
+	if err := json.Unmarshal(raw, &tx); err != nil {
+		t.Fatal(err)
+	}
+	return model.TxnMessage{
+		Tx:      tx,
+		TxIndex: 2,
+		Block: model.BlockDetails{
+			Hash:      "e2b1a1f9f0c0d0e0a0b0c0d0e0f0a1b2c3d4e5f60718293a4b5c6d7e8f901234",
+			Slot:      65000000,
+			Era:       "babbage",
+			Time:      time.Date(2022, 6, 22, 3, 42, 0, 0, time.UTC),
+			Epoch:     350,
+			EpochSlot: 215000,
+			Height:    8500000,
+			Size:      20480,
+			TxCount:   12,
+		},
+		Network: model.Network{Name: "mainnet", Magic: 764824073},
+	}
+}
+
+// golden compares got with the golden file testdata/<name>, or rewrites it with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s does not match:\ngot:  %s\nwant: %s", name, got, want)
+	}
+}
+
+func mustEncode(t *testing.T, name string, options string, message model.TxnMessage) []byte {
+	t.Helper()
+	enc, err := New(name, json.RawMessage(options))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := enc.Encode(message)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return out
+}
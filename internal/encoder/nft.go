@@ -0,0 +1,367 @@
+// internal/encoder/nft.go
+package encoder
+
+import (
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/plutus"
+	"cardano-tx-sync/internal/utils"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync/num"
+)
+
+// cip25Label is the transaction metadata label of CIP-25 NFT metadata.
+const cip25Label = "721"
+
+// CIP-68 asset name labels (CIP-67), as the hex prefix of the asset name. Outputs holding a
+// reference token of a policy watched with the NFT encoder are tracked, to compare datums.
+const (
+	CIP68ReferencePrefix = "000643b0" // (100) reference token
+	cip68NFTPrefix       = "000de140" // (222) NFT
+	cip68FTPrefix        = "0014df10" // (333) FT
+	cip68RFTPrefix       = "001bc280" // (444) RFT
+)
+
+// NFT record standards and events.
+const (
+	nftStandardCIP25 = "cip25"
+	nftStandardCIP68 = "cip68"
+
+	nftEventMint   = "mint"
+	nftEventBurn   = "burn"
+	nftEventUpdate = "update"
+)
+
+func init() {
+	Register(Info{
+		Name:           "NFT",
+		ContentType:    "application/json",
+		SchemaVersion:  "1",
+		Description:    "Normalized NFT records from CIP-25 metadata and CIP-68 reference token datums.",
+		PerMapping:     true,
+		ResolvesInputs: true,
+	}, noOptions(&NFTEncoder{}))
+}
+
+// NFTEncoder extracts NFT records from a transaction: CIP-25 (label 721) metadata of minted assets,
+// burns, and CIP-68 reference token datums, both when the reference token is minted and when it
+// moves with a new datum. A move is only reported as an update when the datum hash differs from
+// the one of the spent output holding the reference token, or when that output is not tracked.
+// For policy ID mappings, only the NFTs of that policy are published. Transactions without NFT
+// records are skipped.
+type NFTEncoder struct{}
+
+type nftMessage struct {
+	TxID    string             `json:"tx_id"`
+	Block   model.BlockDetails `json:"block"`
+	Network model.Network      `json:"network"`
+	NFTs    []nftRecord        `json:"nfts"`
+}
+
+// nftRecord is a normalized NFT record. Asset names are hex-encoded.
+type nftRecord struct {
+	Standard           string                 `json:"standard,omitempty"`
+	Event              string                 `json:"event"`
+	PolicyID           string                 `json:"policy_id"`
+	AssetName          string                 `json:"asset_name,omitempty"`
+	ReferenceAssetName string                 `json:"reference_asset_name,omitempty"`
+	Quantity           string                 `json:"quantity,omitempty"`
+	Name               string                 `json:"name,omitempty"`
+	Image              string                 `json:"image,omitempty"`
+	MediaType          string                 `json:"media_type,omitempty"`
+	Description        string                 `json:"description,omitempty"`
+	Files              interface{}            `json:"files,omitempty"`
+	Attributes         map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// Encode implements the Encoder interface. Without a mapping, the NFTs of all policies are published.
+func (e *NFTEncoder) Encode(message model.TxnMessage) ([]byte, error) {
+	return e.EncodeForMapping(message, model.Mapping{})
+}
+
+// EncodeForMapping implements the MappingEncoder interface.
+func (e *NFTEncoder) EncodeForMapping(message model.TxnMessage, mapping model.Mapping) ([]byte, error) {
+	records, err := nftRecords(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Type == model.MappingTypePolicyID && mapping.Key != "*" {
+		filtered := records[:0]
+		for _, r := range records {
+			if r.PolicyID == mapping.Key {
+				filtered = append(filtered, r)
+			}
+		}
+		records = filtered
+	}
+	if len(records) == 0 {
+		return nil, ErrSkip
+	}
+
+	return json.Marshal(nftMessage{
+		TxID:    message.Tx.ID,
+		Block:   message.Block,
+		Network: message.Network,
+		NFTs:    records,
+	})
+}
+
+func nftRecords(message model.TxnMessage) ([]nftRecord, error) {
+	tx := message.Tx
+	if message.Invalidated {
+		// Mints and outputs of phase-2 invalid transactions are discarded by the ledger.
+		return nil, nil
+	}
+
+	labels, err := metadataLabels(tx.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	cip25 := parseCIP25(labels[cip25Label])
+
+	var records []nftRecord
+	for _, policyID := range utils.SortedPolicies(tx.Mint) {
+		assets := tx.Mint[policyID]
+		for _, assetName := range sortedAssetNames(assets) {
+			qty := assets[assetName]
+			if strings.HasPrefix(assetName, CIP68ReferencePrefix) {
+				// Reference tokens are reported with their datum below.
+				continue
+			}
+			record := nftRecord{PolicyID: policyID, AssetName: assetName, Quantity: qty.String()}
+			if qty.BigInt().Sign() < 0 {
+				record.Standard, record.Event = standardOf(assetName), nftEventBurn
+				records = append(records, record)
+				continue
+			}
+			record.Event = nftEventMint
+			if fields, ok := cip25.lookup(policyID, assetName); ok {
+				record.Standard = nftStandardCIP25
+				applyNFTFields(&record, fields)
+				records = append(records, record)
+			}
+		}
+	}
+
+	for _, out := range tx.Outputs {
+		for _, policyID := range utils.SortedPolicies(out.Value) {
+			for _, assetName := range sortedAssetNames(out.Value[policyID]) {
+				if !strings.HasPrefix(assetName, CIP68ReferencePrefix) || out.Datum == "" {
+					continue
+				}
+				fields, ok := parseCIP68Datum(out.Datum)
+				if !ok {
+					continue
+				}
+				record := nftRecord{
+					Standard:           nftStandardCIP68,
+					Event:              nftEventUpdate,
+					PolicyID:           policyID,
+					ReferenceAssetName: assetName,
+					AssetName:          userTokenName(tx.Mint[policyID], assetName),
+				}
+				if qty, ok := tx.Mint[policyID][assetName]; ok && qty.BigInt().Sign() > 0 {
+					record.Event = nftEventMint
+				} else if previous, ok := spentDatumHash(message.ResolvedInputs, policyID, assetName); ok && previous == plutus.DatumHash(out) {
+					// The reference token moved with the same datum.
+					continue
+				}
+				applyNFTFields(&record, fields)
+				records = append(records, record)
+			}
+		}
+	}
+
+	return records, nil
+}
+
+// cip25Metadata is the label 721 metadata: {policy_id: {asset_name: fields}, "version": ...}.
+type cip25Metadata struct {
+	policies map[string]map[string]map[string]interface{}
+}
+
+func parseCIP25(raw json.RawMessage) cip25Metadata {
+	m := cip25Metadata{policies: make(map[string]map[string]map[string]interface{})}
+	if len(raw) == 0 {
+		return m
+	}
+	var label map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &label); err != nil {
+		return m
+	}
+	for policyID, assetsRaw := range label {
+		var assets map[string]map[string]interface{}
+		if policyID == "version" || json.Unmarshal(assetsRaw, &assets) != nil {
+			continue
+		}
+		m.policies[strings.ToLower(policyID)] = assets
+	}
+	return m
+}
+
+// lookup finds the metadata of an asset. CIP-25 version 1 keys assets by their UTF-8 name and
+// version 2 by their hex-encoded name; both are accepted.
+func (m cip25Metadata) lookup(policyID, assetNameHex string) (map[string]interface{}, bool) {
+	assets, ok := m.policies[policyID]
+	if !ok {
+		return nil, false
+	}
+	if fields, ok := assets[assetNameHex]; ok {
+		return fields, true
+	}
+	for key, fields := range assets {
+		if hex.EncodeToString([]byte(key)) == assetNameHex {
+			return fields, true
+		}
+	}
+	return nil, false
+}
+
+// parseCIP68Datum reads the metadata map of a CIP-68 reference datum: Constr 0 [metadata, version, extra].
+func parseCIP68Datum(datumHex string) (map[string]interface{}, bool) {
+	data, err := plutus.DecodeHex(datumHex)
+	if err != nil {
+		return nil, false
+	}
+	constr, ok := data.(plutus.Constr)
+	if !ok || constr.Index != 0 || len(constr.Fields) < 2 {
+		return nil, false
+	}
+	metadata, ok := constr.Fields[0].(plutus.Map)
+	if !ok {
+		return nil, false
+	}
+	fields, ok := plutusToJSON(metadata).(map[string]interface{})
+	return fields, ok
+}
+
+// plutusToJSON converts CIP-68 metadata to plain JSON values: byte strings become UTF-8 strings
+// when valid, hex otherwise.
+func plutusToJSON(data plutus.Data) interface{} {
+	switch d := data.(type) {
+	case plutus.Bytes:
+		if utf8.Valid(d) {
+			return string(d)
+		}
+		return hex.EncodeToString(d)
+	case plutus.Int:
+		return json.Number(d.String())
+	case plutus.List:
+		items := make([]interface{}, len(d))
+		for i, item := range d {
+			items[i] = plutusToJSON(item)
+		}
+		return items
+	case plutus.Map:
+		obj := make(map[string]interface{}, len(d))
+		for _, pair := range d {
+			key, ok := plutusToJSON(pair.Key).(string)
+			if !ok {
+				keyJSON, _ := json.Marshal(plutusToJSON(pair.Key))
+				key = string(keyJSON)
+			}
+			obj[key] = plutusToJSON(pair.Value)
+		}
+		return obj
+	default:
+		return data
+	}
+}
+
+// applyNFTFields sets the well-known fields of a record. The remaining fields, and the entries of
+// an "attributes" or "traits" object, become attributes.
+func applyNFTFields(record *nftRecord, fields map[string]interface{}) {
+	attributes := make(map[string]interface{})
+	for key, value := range fields {
+		switch key {
+		case "name":
+			record.Name = joinedString(value)
+		case "image":
+			record.Image = joinedString(value)
+		case "mediaType":
+			record.MediaType = joinedString(value)
+		case "description":
+			record.Description = joinedString(value)
+		case "files":
+			record.Files = value
+		case "attributes", "traits":
+			if nested, ok := value.(map[string]interface{}); ok {
+				for k, v := range nested {
+					attributes[k] = v
+				}
+				continue
+			}
+			attributes[key] = value
+		default:
+			attributes[key] = value
+		}
+	}
+	if len(attributes) > 0 {
+		record.Attributes = attributes
+	}
+}
+
+// joinedString returns a string value. CIP-25 splits strings longer than 64 bytes into arrays.
+func joinedString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		var sb strings.Builder
+		for _, part := range v {
+			if s, ok := part.(string); ok {
+				sb.WriteString(s)
+			}
+		}
+		return sb.String()
+	default:
+		return ""
+	}
+}
+
+// standardOf tells from its CIP-67 label whether a burned asset is a CIP-68 token. Other burned
+// assets have no known standard, as CIP-25 metadata is not repeated on burns.
+func standardOf(assetName string) string {
+	for _, prefix := range []string{cip68NFTPrefix, cip68FTPrefix, cip68RFTPrefix} {
+		if strings.HasPrefix(assetName, prefix) {
+			return nftStandardCIP68
+		}
+	}
+	return ""
+}
+
+// spentDatumHash returns the datum hash of the resolved input holding an asset. The boolean is
+// false when no resolved input holds it.
+func spentDatumHash(inputs []model.ResolvedInput, policyID, assetName string) (string, bool) {
+	for _, in := range inputs {
+		if _, ok := in.Value[policyID][assetName]; ok {
+			return in.DatumHash, true
+		}
+	}
+	return "", false
+}
+
+// userTokenName finds the user token minted alongside a reference token, if any.
+func userTokenName(minted map[string]num.Int, referenceName string) string {
+	suffix := strings.TrimPrefix(referenceName, CIP68ReferencePrefix)
+	for _, prefix := range []string{cip68NFTPrefix, cip68FTPrefix, cip68RFTPrefix} {
+		if _, ok := minted[prefix+suffix]; ok {
+			return prefix + suffix
+		}
+	}
+	return ""
+}
+
+func sortedAssetNames(assets map[string]num.Int) []string {
+	names := make([]string, 0, len(assets))
+	for name := range assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
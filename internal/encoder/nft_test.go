@@ -0,0 +1,76 @@
+// internal/encoder/nft_test.go
+package encoder
+
+import (
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/plutus"
+	"encoding/json"
+	"testing"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync/num"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+)
+
+const (
+	referenceToken = CIP68ReferencePrefix + "4e4654"
+	// cip68DatumA and cip68DatumB are CIP-68 datums named "A" and "B".
+	cip68DatumA = "d8799fa1446e616d65414101ff"
+	cip68DatumB = "d8799fa1446e616d65414201ff"
+)
+
+func TestNFTReferenceTokenUpdate(t *testing.T) {
+	spent := func(datum string) []model.ResolvedInput {
+		return []model.ResolvedInput{{
+			Value:     shared.Value{extremePolicy: {referenceToken: num.Int64(1)}},
+			DatumHash: plutus.DatumHash(chainsync.TxOut{Datum: datum}),
+		}}
+	}
+	tests := []struct {
+		name     string
+		resolved []model.ResolvedInput
+		want     int
+	}{
+		{name: "spent output not tracked", want: 1},
+		{name: "datum changed", resolved: spent(cip68DatumA), want: 1},
+		{name: "datum unchanged", resolved: spent(cip68DatumB), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := testMessage(t)
+			message.Tx.Mint = nil
+			message.Tx.Metadata = nil
+			message.Tx.Outputs = chainsync.TxOuts{{
+				Address: message.Tx.Outputs[1].Address,
+				Datum:   cip68DatumB,
+				Value: shared.Value{
+					shared.AdaPolicy: {shared.AdaAsset: num.Int64(2000000)},
+					extremePolicy:    {referenceToken: num.Int64(1)},
+				},
+			}}
+			message.ResolvedInputs = tt.resolved
+
+			enc, err := New("NFT", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			raw, err := enc.Encode(message)
+			if tt.want == 0 {
+				if err != ErrSkip {
+					t.Errorf("error = %v, want the transaction skipped", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out nftMessage
+			if err := json.Unmarshal(raw, &out); err != nil {
+				t.Fatal(err)
+			}
+			if len(out.NFTs) != tt.want || out.NFTs[0].Event != nftEventUpdate || out.NFTs[0].Name != "B" {
+				t.Errorf("records are %+v, want an update to B", out.NFTs)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// internal/encoder/projection.go
+package encoder
+
+import (
+	"cardano-tx-sync/internal/model"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+)
+
+// metadataFieldPrefix selects a single metadata label, e.g. "metadata.721".
+const metadataFieldPrefix = "metadata."
+
+func init() {
+	Register(Info{
+		Name:          "PROJECTION",
+		ContentType:   "application/json",
+		SchemaVersion: "1",
+		Description:   "Compact JSON with only the fields listed in the mapping's encoder options.",
+		PerMapping:    true,
+	}, func(options json.RawMessage) (Encoder, error) {
+		var o projectionOptions
+		if err := decodeOptions(options, &o); err != nil {
+			return nil, err
+		}
+		enc, err := NewProjectionEncoder(o.Fields)
+		if err != nil {
+			return nil, err
+		}
+		enc.legacyQuantities = legacyQuantities(o.LegacyInt64Quantities)
+		return enc, nil
+	})
+}
+
+// projectionOptions are the per-mapping options of the PROJECTION encoder.
+type projectionOptions struct {
+	Fields                []string `json:"fields"`
+	LegacyInt64Quantities *bool    `json:"legacy_int64_quantities"`
+}
+
+// projector extracts the value of a projected field.
+type projector func(message model.TxnMessage, mapping model.Mapping) interface{}
+
+// projectors are the fields that can be projected, by name.
+var projectors = map[string]projector{
+	"tx_id":          func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Tx.ID },
+	"tx_index":       func(m model.TxnMessage, _ model.Mapping) interface{} { return m.TxIndex },
+	"block_hash":     func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.Hash },
+	"block_slot":     func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.Slot },
+	"block_era":      func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.Era },
+	"block_time":     func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.Time },
+	"epoch":          func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.Epoch },
+	"epoch_slot":     func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.EpochSlot },
+	"block_height":   func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.Height },
+	"block_issuer":   func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.IssuerPoolID },
+	"block_size":     func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.Size },
+	"block_tx_count": func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Block.TxCount },
+	"network":        func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Network },
+	"invalidated":    func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Invalidated },
+	"fee":            func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Tx.Fee.AdaLovelace() },
+	"inputs":         func(m model.TxnMessage, _ model.Mapping) interface{} { return nonNil(m.Tx.Inputs) },
+	"references":     func(m model.TxnMessage, _ model.Mapping) interface{} { return nonNil(m.Tx.References) },
+	"outputs":        func(m model.TxnMessage, _ model.Mapping) interface{} { return nonNil(m.Tx.Outputs) },
+	"watched_outputs": func(m model.TxnMessage, mapping model.Mapping) interface{} {
+		return watchedOutputs(m.Tx.Outputs, mapping)
+	},
+	"mint":                       func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Tx.Mint },
+	"withdrawals":                func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Tx.Withdrawals },
+	"certificates":               func(m model.TxnMessage, _ model.Mapping) interface{} { return nonNil(m.Tx.Certificates) },
+	"validity_interval":          func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Tx.ValidityInterval },
+	"valid_from":                 func(m model.TxnMessage, _ model.Mapping) interface{} { return m.ValidFrom },
+	"valid_until":                func(m model.TxnMessage, _ model.Mapping) interface{} { return m.ValidUntil },
+	"required_extra_signatories": func(m model.TxnMessage, _ model.Mapping) interface{} { return nonNil(m.Tx.RequiredExtraSignatories) },
+	"datums":                     func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Tx.Datums },
+	"redeemers":                  func(m model.TxnMessage, _ model.Mapping) interface{} { return optionalRaw(m.Tx.Redeemers) },
+	"metadata":                   func(m model.TxnMessage, _ model.Mapping) interface{} { return optionalRaw(m.Tx.Metadata) },
+	"cbor":                       func(m model.TxnMessage, _ model.Mapping) interface{} { return m.Tx.CBOR },
+	"typed_data":                 func(m model.TxnMessage, _ model.Mapping) interface{} { return optionalRaw(m.TypedData) },
+}
+
+// ProjectionEncoder encodes only the configured fields of the message as a flat JSON object.
+// Metadata labels selected with "metadata.<label>" are grouped under "metadata_labels".
+type ProjectionEncoder struct {
+	fields []string
+	labels []string
+	// legacyQuantities writes native asset quantities as JSON numbers instead of decimal strings.
+	legacyQuantities bool
+}
+
+// NewProjectionEncoder creates an encoder projecting the given fields. At least one field is required.
+func NewProjectionEncoder(fields []string) (*ProjectionEncoder, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required")
+	}
+
+	e := &ProjectionEncoder{}
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if seen[field] {
+			return nil, fmt.Errorf("duplicate field: %s", field)
+		}
+		seen[field] = true
+
+		if label, ok := strings.CutPrefix(field, metadataFieldPrefix); ok {
+			if label == "" {
+				return nil, fmt.Errorf("missing metadata label in field: %s", field)
+			}
+			e.labels = append(e.labels, label)
+			continue
+		}
+		if _, ok := projectors[field]; !ok {
+			return nil, fmt.Errorf("unknown field: %s", field)
+		}
+		e.fields = append(e.fields, field)
+	}
+	return e, nil
+}
+
+// Encode implements the Encoder interface. Without a mapping, watched_outputs holds every output.
+func (e *ProjectionEncoder) Encode(message model.TxnMessage) ([]byte, error) {
+	return e.EncodeForMapping(message, model.Mapping{})
+}
+
+// EncodeForMapping implements the MappingEncoder interface.
+func (e *ProjectionEncoder) EncodeForMapping(message model.TxnMessage, mapping model.Mapping) ([]byte, error) {
+	out := make(map[string]interface{}, len(e.fields)+1)
+	for _, field := range e.fields {
+		value := projectors[field](message, mapping)
+		if !e.legacyQuantities {
+			value = withJSONQuantities(value)
+		}
+		out[field] = value
+	}
+
+	if len(e.labels) > 0 {
+		labels, err := metadataLabels(message.Tx.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		selected := make(map[string]json.RawMessage, len(e.labels))
+		for _, label := range e.labels {
+			if value, ok := labels[label]; ok {
+				selected[label] = value
+			}
+		}
+		out["metadata_labels"] = selected
+	}
+
+	return json.Marshal(out)
+}
+
+// withJSONQuantities converts projected outputs and values to their jsonValue form, which writes
+// native asset quantities as decimal strings. Other fields are returned as is.
+func withJSONQuantities(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []chainsync.TxOut:
+		return newJSONTxOuts(v, false)
+	case shared.Value:
+		if v == nil {
+			return nil
+		}
+		return jsonValue{value: v}
+	case map[string]shared.Value:
+		if v == nil {
+			return nil
+		}
+		withdrawals := newJSONWithdrawals(v, false)
+		if withdrawals == nil {
+			withdrawals = map[string]jsonValue{}
+		}
+		return withdrawals
+	default:
+		return value
+	}
+}
+
+// watchedOutputs keeps the outputs relevant to the mapping: outputs to the watched address for
+// address mappings, and outputs holding an asset of the watched policy for policy ID mappings.
+// Wildcard and other mappings keep every output.
+func watchedOutputs(outputs chainsync.TxOuts, mapping model.Mapping) []chainsync.TxOut {
+	if mapping.Key == "*" || (mapping.Type != model.MappingTypeAddress && mapping.Type != model.MappingTypePolicyID) {
+		return nonNil(outputs)
+	}
+
+	watched := []chainsync.TxOut{}
+	for _, output := range outputs {
+		switch mapping.Type {
+		case model.MappingTypeAddress:
+			if output.Address == mapping.Key {
+				watched = append(watched, output)
+			}
+		case model.MappingTypePolicyID:
+			if _, ok := output.Value[mapping.Key]; ok {
+				watched = append(watched, output)
+			}
+		}
+	}
+	return watched
+}
+
+// metadataLabels returns the metadata of each label, in the detailed JSON form when Ogmios
+// provides one and as the raw label object otherwise.
+func metadataLabels(metadata json.RawMessage) (map[string]json.RawMessage, error) {
+	if len(metadata) == 0 || string(metadata) == "null" {
+		return nil, nil
+	}
+
+	var parsed struct {
+		Labels map[string]json.RawMessage `json:"labels"`
+	}
+	if err := json.Unmarshal(metadata, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	labels := make(map[string]json.RawMessage, len(parsed.Labels))
+	for label, raw := range parsed.Labels {
+		var value struct {
+			JSON json.RawMessage `json:"json"`
+		}
+		if err := json.Unmarshal(raw, &value); err == nil && len(value.JSON) > 0 {
+			labels[label] = value.JSON
+		} else {
+			labels[label] = raw
+		}
+	}
+	return labels, nil
+}
+
+// optionalRaw returns nil for empty JSON, which cannot be marshaled as a json.RawMessage.
+func optionalRaw(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return raw
+}
+
+// nonNil returns an empty slice for nil, so that projected lists are encoded as [] rather than null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
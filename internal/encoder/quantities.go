@@ -0,0 +1,177 @@
+// internal/encoder/quantities.go
+package encoder
+
+import (
+	"bytes"
+	"cardano-tx-sync/internal/address"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+)
+
+// jsonValue is a value in the Ogmios representation, except that native asset quantities are
+// written as decimal strings: they can exceed 2^63-1, and most JSON parsers round or reject
+// integers that large. Lovelace amounts fit in an int64 and stay numbers. With legacy set, the
+// value is written exactly as Ogmios does.
+type jsonValue struct {
+	value  shared.Value
+	legacy bool
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v jsonValue) MarshalJSON() ([]byte, error) {
+	if v.legacy {
+		return json.Marshal(v.value)
+	}
+	out := make(map[string]map[string]interface{}, len(v.value))
+	for policyID, assets := range v.value {
+		quantities := make(map[string]interface{}, len(assets))
+		for assetName, qty := range assets {
+			if policyID == shared.AdaPolicy {
+				quantities[assetName] = qty
+			} else {
+				quantities[assetName] = qty.String()
+			}
+		}
+		out[policyID] = quantities
+	}
+	return json.Marshal(out)
+}
+
+// newJSONValue returns nil for an empty value, so that it is omitted like the Ogmios one.
+func newJSONValue(value shared.Value, legacy bool) *jsonValue {
+	if len(value) == 0 {
+		return nil
+	}
+	return &jsonValue{value: value, legacy: legacy}
+}
+
+// jsonTxOut is an output with its value written as a jsonValue, and optionally its decoded address.
+type jsonTxOut struct {
+	Address   string          `json:"address,omitempty"`
+	Datum     string          `json:"datum,omitempty"`
+	DatumHash string          `json:"datumHash,omitempty"`
+	Value     *jsonValue      `json:"value,omitempty"`
+	Script    json.RawMessage `json:"script,omitempty"`
+	// AddressDetails is the decoded address. It is omitted for addresses that cannot be decoded.
+	AddressDetails *address.Details `json:"addressDetails,omitempty"`
+}
+
+func newJSONTxOut(out chainsync.TxOut, legacy bool) jsonTxOut {
+	return jsonTxOut{
+		Address:   out.Address,
+		Datum:     out.Datum,
+		DatumHash: out.DatumHash,
+		Value:     newJSONValue(out.Value, legacy),
+		Script:    out.Script,
+	}
+}
+
+func newJSONTxOuts(outputs []chainsync.TxOut, legacy bool) []jsonTxOut {
+	out := make([]jsonTxOut, 0, len(outputs))
+	for _, output := range outputs {
+		out = append(out, newJSONTxOut(output, legacy))
+	}
+	return out
+}
+
+func newJSONWithdrawals(withdrawals map[string]shared.Value, legacy bool) map[string]jsonValue {
+	if len(withdrawals) == 0 {
+		return nil
+	}
+	out := make(map[string]jsonValue, len(withdrawals))
+	for account, value := range withdrawals {
+		out[account] = jsonValue{value: value, legacy: legacy}
+	}
+	return out
+}
+
+// legacyQuantities returns the legacy_int64_quantities option of a mapping, falling back to the
+// encoder configuration when unset.
+func legacyQuantities(option *bool) bool {
+	if option != nil {
+		return *option
+	}
+	return transformOptions.LegacyInt64Quantities
+}
+
+// jsonObject is a marshalled JSON object whose fields can be replaced without changing their
+// order, so that an encoder can rewrite some fields of a marshalled Ogmigo or protobuf type and
+// still write the others exactly as earlier releases did.
+type jsonObject struct {
+	names  []string
+	values []json.RawMessage
+}
+
+func parseJSONObject(raw []byte) (*jsonObject, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token != json.Delim('{') {
+		return nil, fmt.Errorf("not a JSON object: %s", raw)
+	}
+
+	o := &jsonObject{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		o.names = append(o.names, token.(string))
+		o.values = append(o.values, value)
+	}
+	return o, nil
+}
+
+// get returns the value of a field, nil if the object does not have it.
+func (o *jsonObject) get(name string) json.RawMessage {
+	for i, n := range o.names {
+		if n == name {
+			return o.values[i]
+		}
+	}
+	return nil
+}
+
+// set marshals the value of a field in place, or appends the field when the object does not have it.
+func (o *jsonObject) set(name string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	for i, n := range o.names {
+		if n == name {
+			o.values[i] = raw
+			return nil
+		}
+	}
+	o.names = append(o.names, name)
+	o.values = append(o.values, raw)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range o.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(o.values[i])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,384 @@
+// internal/encoder/quantities_test.go
+package encoder
+
+import (
+	"bytes"
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/pb"
+	"cardano-tx-sync/internal/utils"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync/num"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/hamba/avro/v2"
+)
+
+// extremePolicy holds one asset per extreme quantity, named by its index in extremeQuantities.
+const extremePolicy = "0ba5e0f3c5b0d5f2f1a2e3d4c5b6a7980a1b2c3d4e5f60718293a4b5"
+
+// extremeQuantities are 0, 2^63-1, 2^63 and 2^64-1.
+var extremeQuantities = []string{"0", "9223372036854775807", "9223372036854775808", "18446744073709551615"}
+
+var extremeAssetNames = []string{"00", "01", "02", "03"}
+
+// extremeMessage returns the test message with an output holding, and a mint of, every extreme
+// quantity.
+func extremeMessage(t *testing.T) model.TxnMessage {
+	t.Helper()
+	message := testMessage(t)
+	assets := make(map[string]num.Int, len(extremeQuantities))
+	for i, s := range extremeQuantities {
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			t.Fatalf("invalid quantity %s", s)
+		}
+		assets[extremeAssetNames[i]] = num.Int(*n)
+	}
+	message.Tx.Outputs = chainsync.TxOuts{{
+		Address: message.Tx.Outputs[1].Address,
+		Value: shared.Value{
+			shared.AdaPolicy: {shared.AdaAsset: num.Int64(2000000)},
+			extremePolicy:    assets,
+		},
+	}}
+	message.Tx.Mint = shared.Value{extremePolicy: assets}
+	message.Tx.Metadata = nil
+	return message
+}
+
+// legacyOption selects the deprecated quantity format for a mapping.
+const legacyOption = `{"legacy_int64_quantities": true}`
+
+// projectedValueFields are the PROJECTION fields holding values.
+const projectedValueFields = `["outputs", "watched_outputs", "mint", "withdrawals"]`
+
+// decodeJSON parses a JSON message, keeping numbers as json.Number.
+func decodeJSON(t *testing.T, raw []byte) map[string]interface{} {
+	t.Helper()
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var out map[string]interface{}
+	if err := decoder.Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// checkJSONQuantities checks the extreme assets of a value in the Ogmios JSON representation. In
+// the lossless format the quantities are strings, in the legacy format exact integer literals.
+func checkJSONQuantities(t *testing.T, where string, value interface{}, legacy bool) {
+	t.Helper()
+	policies, ok := value.(map[string]interface{})
+	if !ok {
+		t.Fatalf("%s: value is %T", where, value)
+	}
+	assets, _ := policies[extremePolicy].(map[string]interface{})
+	for i, name := range extremeAssetNames {
+		var want interface{} = extremeQuantities[i]
+		if legacy {
+			want = json.Number(extremeQuantities[i])
+		}
+		if got := assets[name]; got != want {
+			t.Errorf("%s: quantity of %s is %#v, want %#v", where, name, got, want)
+		}
+	}
+	if ada, ok := policies["ada"]; ok {
+		if got := ada.(map[string]interface{})["lovelace"]; got != json.Number("2000000") {
+			t.Errorf("%s: lovelace is %#v, want the number 2000000", where, got)
+		}
+	}
+}
+
+// checkLegacyGolden checks that the legacy format reproduces byte for byte the output of the
+// release before lossless quantities, pinned by a golden file written by that release. The fields
+// at the given paths are removed from both first: fields added since, and the few whose value was
+// changed on purpose. A "*" path element matches every element of an array.
+func checkLegacyGolden(t *testing.T, name string, got []byte, ignored ...string) {
+	t.Helper()
+	want, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = bytes.TrimSuffix(want, []byte("\n"))
+	for _, path := range ignored {
+		got = withoutField(t, got, strings.Split(path, "."))
+		want = withoutField(t, want, strings.Split(path, "."))
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("legacy output differs from %s:\ngot:  %s\nwant: %s", name, got, want)
+	}
+}
+
+// withoutField removes the field at path from a JSON value, keeping the other fields and their
+// order as they are.
+func withoutField(t *testing.T, raw []byte, path []string) []byte {
+	t.Helper()
+	if path[0] == "*" {
+		var elements []json.RawMessage
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			t.Fatal(err)
+		}
+		for i, element := range elements {
+			elements[i] = withoutField(t, element, path[1:])
+		}
+		out, err := json.Marshal(elements)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	object, err := parseJSONObject(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := &jsonObject{}
+	for i, name := range object.names {
+		value := object.values[i]
+		if name == path[0] {
+			if len(path) == 1 {
+				continue
+			}
+			value = withoutField(t, value, path[1:])
+		}
+		kept.names = append(kept.names, name)
+		kept.values = append(kept.values, value)
+	}
+	out, err := kept.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// defaultAddedFields are the fields of DEFAULT messages added since the legacy golden files were
+// written.
+var defaultAddedFields = []string{
+	"txIndex", "network", "block.time", "block.epoch", "block.epochSlot", "block.height",
+	"block.issuerPoolId", "block.size", "block.txCount", "tx.outputs.*.addressDetails",
+	"validFrom", "validUntil",
+}
+
+func TestDefaultQuantities(t *testing.T) {
+	message := extremeMessage(t)
+
+	out := decodeJSON(t, mustEncode(t, "DEFAULT", "", message))
+	tx := out["tx"].(map[string]interface{})
+	output := tx["outputs"].([]interface{})[0].(map[string]interface{})
+	checkJSONQuantities(t, "output", output["value"], false)
+	checkJSONQuantities(t, "mint", tx["mint"], false)
+	if output["addressDetails"] == nil {
+		t.Error("output address is not decoded")
+	}
+
+	raw := mustEncode(t, "DEFAULT", legacyOption, message)
+	checkLegacyGolden(t, "quantities_default_legacy.json", raw, defaultAddedFields...)
+	tx = decodeJSON(t, raw)["tx"].(map[string]interface{})
+	checkJSONQuantities(t, "legacy output", tx["outputs"].([]interface{})[0].(map[string]interface{})["value"], true)
+	checkJSONQuantities(t, "legacy mint", tx["mint"], true)
+}
+
+func TestProjectionQuantities(t *testing.T) {
+	message := extremeMessage(t)
+
+	out := decodeJSON(t, mustEncode(t, "PROJECTION", `{"fields": `+projectedValueFields+`}`, message))
+	for _, field := range []string{"outputs", "watched_outputs"} {
+		checkJSONQuantities(t, field, out[field].([]interface{})[0].(map[string]interface{})["value"], false)
+	}
+	checkJSONQuantities(t, "mint", out["mint"], false)
+
+	// The legacy values are written as the earlier DEFAULT messages pinned by its golden file.
+	raw := mustEncode(t, "PROJECTION", `{"fields": `+projectedValueFields+`, "legacy_int64_quantities": true}`, message)
+	projected, err := parseJSONObject(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "quantities_default_legacy.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, err := parseJSONObject(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := parseJSONObject(full.get("tx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for field, from := range map[string]string{"outputs": "outputs", "watched_outputs": "outputs", "mint": "mint", "withdrawals": "withdrawals"} {
+		if got, want := projected.get(field), tx.get(from); !bytes.Equal(got, want) {
+			t.Errorf("legacy %s is %s, want %s", field, got, want)
+		}
+	}
+}
+
+// checkEventQuantities checks the extreme assets of the first output and of the mint of a DANOGO
+// event. In the legacy format quantities above 2^63-1 wrap around, as they always have.
+func checkEventQuantities(t *testing.T, encoder string, event *pb.CardanoTransactionEvent, legacy bool) {
+	t.Helper()
+	body := event.GetTransaction().GetBody()
+	if len(body.GetOutputs()) == 0 {
+		t.Fatalf("%s: no outputs", encoder)
+	}
+	value := body.GetOutputs()[0].GetValue()
+	mint := body.GetMint()
+	for i, name := range extremeAssetNames {
+		asset := utils.GetAsset(extremePolicy, name)
+		if legacy {
+			n, _ := new(big.Int).SetString(extremeQuantities[i], 10)
+			want := int64(n.Uint64())
+			if got := value.GetAssets()[asset]; got != want {
+				t.Errorf("%s: legacy output quantity of %s is %d, want %d", encoder, name, got, want)
+			}
+			if got := mint.GetAssets()[asset]; got != want {
+				t.Errorf("%s: legacy mint quantity of %s is %d, want %d", encoder, name, got, want)
+			}
+			continue
+		}
+		if got := value.GetAssetQuantities()[asset]; got != extremeQuantities[i] {
+			t.Errorf("%s: output quantity of %s is %q, want %q", encoder, name, got, extremeQuantities[i])
+		}
+		if got := mint.GetAssetQuantities()[asset]; got != extremeQuantities[i] {
+			t.Errorf("%s: mint quantity of %s is %q, want %q", encoder, name, got, extremeQuantities[i])
+		}
+	}
+	if legacy && (len(value.GetAssetQuantities()) > 0 || len(mint.GetAssetQuantities()) > 0) {
+		t.Errorf("%s: legacy event has decimal quantities", encoder)
+	}
+	if !legacy && (len(value.GetAssets()) > 0 || len(mint.GetAssets()) > 0) {
+		t.Errorf("%s: event has legacy int64 quantities", encoder)
+	}
+}
+
+func TestDanogoQuantities(t *testing.T) {
+	message := extremeMessage(t)
+	for _, legacy := range []bool{false, true} {
+		options := ""
+		if legacy {
+			options = legacyOption
+		}
+
+		// Plain DANOGO JSON is not the protobuf JSON mapping, so only the values are parsed back.
+		var plain struct {
+			Transaction struct {
+				Body struct {
+					Outputs []struct {
+						Value *pb.OutputValue `json:"value"`
+					} `json:"outputs"`
+					Mint *pb.MintValue `json:"mint"`
+				} `json:"body"`
+			} `json:"transaction"`
+		}
+		if err := json.Unmarshal(mustEncode(t, "DANOGO", options, message), &plain); err != nil {
+			t.Fatal(err)
+		}
+		body := &pb.TxBody{Mint: plain.Transaction.Body.Mint}
+		for _, out := range plain.Transaction.Body.Outputs {
+			body.Outputs = append(body.Outputs, &pb.TxOutput{Value: out.Value})
+		}
+		checkEventQuantities(t, "DANOGO", &pb.CardanoTransactionEvent{Transaction: &pb.CardanoTransaction{Body: body}}, legacy)
+
+		var event pb.CardanoTransactionEvent
+		if err := jsonpb.Unmarshal(bytes.NewReader(mustEncode(t, "DANOGO_JSON", options, message)), &event); err != nil {
+			t.Fatal(err)
+		}
+		checkEventQuantities(t, "DANOGO_JSON", &event, legacy)
+
+		event = pb.CardanoTransactionEvent{}
+		if err := proto.Unmarshal(mustEncode(t, "DANOGO_PROTOBUF", options, message), &event); err != nil {
+			t.Fatal(err)
+		}
+		checkEventQuantities(t, "DANOGO_PROTOBUF", &event, legacy)
+	}
+}
+
+func TestAvroQuantities(t *testing.T) {
+	enc := &AvroEncoder{schema: avro.MustParse(txnMessageSchemaJSON), schemaID: 1}
+	raw, err := enc.Encode(extremeMessage(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded avroTxnMessage
+	if err := avro.Unmarshal(enc.schema, raw[5:], &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range extremeAssetNames {
+		asset := utils.GetAsset(extremePolicy, name)
+		if got := decoded.Tx.Outputs[0].Assets[asset]; got != extremeQuantities[i] {
+			t.Errorf("output quantity of %s is %q, want %q", name, got, extremeQuantities[i])
+		}
+		if got := decoded.Tx.Mint[asset]; got != extremeQuantities[i] {
+			t.Errorf("mint quantity of %s is %q, want %q", name, got, extremeQuantities[i])
+		}
+	}
+}
+
+func TestNFTQuantities(t *testing.T) {
+	// Burns are reported without metadata, so burn every extreme quantity.
+	message := extremeMessage(t)
+	burn := make(map[string]num.Int)
+	for name, qty := range message.Tx.Mint[extremePolicy] {
+		burn[name] = num.Int(*new(big.Int).Neg(qty.BigInt()))
+	}
+	message.Tx.Mint = shared.Value{extremePolicy: burn}
+
+	var out nftMessage
+	if err := json.Unmarshal(mustEncode(t, "NFT", "", message), &out); err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, record := range out.NFTs {
+		got[record.AssetName] = record.Quantity
+	}
+	// A burn of 0 is no burn.
+	want := map[string]string{
+		"01": "-9223372036854775807",
+		"02": "-9223372036854775808",
+		"03": "-18446744073709551615",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("burned quantities are %v, want %v", got, want)
+	}
+}
+
+func TestBalanceQuantities(t *testing.T) {
+	message := extremeMessage(t)
+	mapping := model.Mapping{Type: model.MappingTypeAddress, Key: message.Tx.Outputs[0].Address}
+	enc, err := New("BALANCE", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := enc.(MappingEncoder).EncodeForMapping(message, mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out balanceMessage
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatal(err)
+	}
+	// Assets with no net change, here the quantity 0, are left out.
+	want := map[string]string{}
+	for i, name := range extremeAssetNames[1:] {
+		want[name] = extremeQuantities[i+1]
+	}
+	if !reflect.DeepEqual(out.Delta.Assets[extremePolicy], want) {
+		t.Errorf("received quantities are %v, want %v", out.Delta.Assets[extremePolicy], want)
+	}
+	if out.Delta.Lovelace != strconv.Itoa(2000000) {
+		t.Errorf("received lovelace is %s, want 2000000", out.Delta.Lovelace)
+	}
+}
+
+// SIMPLE and CBOR carry no decoded quantities: CBOR publishes the transaction bytes unchanged.
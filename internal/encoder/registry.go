@@ -0,0 +1,114 @@
+// internal/encoder/registry.go
+package encoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Info describes a registered encoder.
+type Info struct {
+	Name          string `json:"name"`
+	ContentType   string `json:"content_type"`
+	SchemaVersion string `json:"schema_version"`
+	Description   string `json:"description"`
+	// PerMapping is set for encoders implementing MappingEncoder. Their messages are encoded once
+	// per matched mapping rather than once per transaction.
+	PerMapping bool `json:"per_mapping"`
+	// ResolvesInputs is set for encoders that need the spent outputs of the watched entity. Address
+	// mappings using them also match transactions spending from the address.
+	ResolvesInputs bool `json:"resolves_inputs"`
+}
+
+// Factory creates an encoder from the options stored with a mapping. Options may be empty.
+type Factory func(options json.RawMessage) (Encoder, error)
+
+type registration struct {
+	info    Info
+	factory Factory
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]registration)
+)
+
+// Register makes an encoder available by name. Names are case-insensitive.
+// It panics if an encoder with the same name is already registered.
+func Register(info Info, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	info.Name = strings.ToUpper(info.Name)
+	if _, exists := registry[info.Name]; exists {
+		panic(fmt.Sprintf("encoder %s is already registered", info.Name))
+	}
+	registry[info.Name] = registration{info: info, factory: factory}
+}
+
+// New creates an encoder by name, configured with the given per-mapping options.
+func New(name string, options json.RawMessage) (Encoder, error) {
+	registryMu.RLock()
+	reg, ok := registry[strings.ToUpper(name)]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown encoder: %s", name)
+	}
+
+	enc, err := reg.factory(options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options for encoder %s: %w", reg.info.Name, err)
+	}
+	return enc, nil
+}
+
+// Validate checks that the encoder exists and accepts the given options.
+func Validate(name string, options json.RawMessage) error {
+	_, err := New(name, options)
+	return err
+}
+
+// Lookup returns the description of a registered encoder.
+func Lookup(name string) (Info, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	reg, ok := registry[strings.ToUpper(name)]
+	return reg.info, ok
+}
+
+// List returns the descriptions of all registered encoders, sorted by name.
+func List() []Info {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	infos := make([]Info, 0, len(registry))
+	for _, reg := range registry {
+		infos = append(infos, reg.info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// decodeOptions strictly decodes per-mapping options into v. Empty options leave v untouched.
+func decodeOptions(options json.RawMessage, v interface{}) error {
+	if len(options) == 0 || string(options) == "null" {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(options))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// noOptions is the factory helper for encoders that take no options.
+func noOptions(enc Encoder) Factory {
+	return func(options json.RawMessage) (Encoder, error) {
+		if err := decodeOptions(options, &struct{}{}); err != nil {
+			return nil, err
+		}
+		return enc, nil
+	}
+}
@@ -6,15 +6,26 @@ import (
 	"encoding/json"
 )
 
+func init() {
+	Register(Info{
+		Name:          "SIMPLE",
+		ContentType:   "application/json",
+		SchemaVersion: "1",
+		Description:   "Transaction ID and network magic only, as JSON.",
+	}, noOptions(&SimpleEncoder{}))
+}
+
 // SimpleEncoder encodes the message with only the transaction ID.
 type SimpleEncoder struct{}
 
 // Encode implements the Encoder interface.
 func (e *SimpleEncoder) Encode(message model.TxnMessage) ([]byte, error) {
 	simpleMsg := struct {
-		TxID string `json:"txId"`
+		TxID         string `json:"txId"`
+		NetworkMagic uint32 `json:"networkMagic"`
 	}{
-		TxID: message.Tx.ID,
+		TxID:         message.Tx.ID,
+		NetworkMagic: message.Network.Magic,
 	}
 	return json.Marshal(simpleMsg)
 }
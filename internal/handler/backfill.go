@@ -0,0 +1,97 @@
+// internal/handler/backfill.go
+package handler
+
+import (
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/plutus"
+	"cardano-tx-sync/internal/utils"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+	"go.uber.org/zap"
+)
+
+// ledgerState queries the ledger state from Ogmios.
+type ledgerState interface {
+	ChainTip(ctx context.Context) (chainsync.Point, error)
+	UtxosByAddress(ctx context.Context, addresses ...string) ([]shared.Utxo, error)
+}
+
+// Backfill tracks the current unspent outputs of the address watched by a mapping, so that spends
+// of outputs created before the mapping was added are resolved. Only address mappings whose
+// encoder resolves inputs are backfilled: Ogmios cannot query the outputs of a stake credential.
+func (h *BlockHandler) Backfill(ctx context.Context, m model.Mapping) error {
+	if m.Type != model.MappingTypeAddress || m.Key == "*" || !resolvesInputs(m.Encoder) {
+		return nil
+	}
+	utxos, err := h.ledger.UtxosByAddress(ctx, m.Key)
+	if err != nil {
+		return fmt.Errorf("failed to query outputs of %s: %w", m.Key, err)
+	}
+	// The tip is queried after the outputs, so that the snapshot is not later than its slot.
+	tip, err := h.ledger.ChainTip(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query ledger tip: %w", err)
+	}
+	var slot uint64
+	if pointStruct, ok := tip.PointStruct(); ok {
+		slot = pointStruct.Slot
+	}
+
+	outputs := make([]model.TrackedOutput, 0, len(utxos))
+	for _, utxo := range utxos {
+		value, err := json.Marshal(utxo.Value)
+		if err != nil {
+			return fmt.Errorf("failed to encode value of %s#%d: %w", utxo.Transaction.ID, utxo.Index, err)
+		}
+		outputs = append(outputs, model.TrackedOutput{
+			OutputRef: model.OutputRef{TxID: utxo.Transaction.ID, Index: int(utxo.Index)},
+			Address:   utxo.Address,
+			Value:     value,
+			DatumHash: plutus.DatumHash(chainsync.TxOut{Datum: utxo.Datum, DatumHash: utxo.DatumHash}),
+		})
+	}
+	if err := h.storage.SaveBackfill(m.ID, outputs, slot); err != nil {
+		return fmt.Errorf("failed to save backfilled outputs: %w", err)
+	}
+	h.logger.Info("backfilled tracked outputs",
+		zap.Int("mapping_id", m.ID), zap.String("address", m.Key), zap.Int("outputs", len(outputs)), zap.Uint64("slot", slot))
+	return nil
+}
+
+// BackfillMappings backfills the address mappings that were not, such as those added before
+// backfills existed or whose backfill failed.
+func (h *BlockHandler) BackfillMappings(ctx context.Context) error {
+	mappings, err := h.storage.GetMappingsToBackfill()
+	if err != nil {
+		return fmt.Errorf("failed to get mappings to backfill: %w", err)
+	}
+	for _, m := range mappings {
+		if err := h.Backfill(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unresolvedInputs tells whether a message for the mapping of an address or stake credential may
+// miss resolved inputs: the transaction spends inputs that were not resolved, and outputs of the
+// entity created before it was watched are unknown at the slot of the block.
+func (h *BlockHandler) unresolvedInputs(spec encoderSpec, msg model.TxnMessage) bool {
+	if spec.mappingType != model.MappingTypeAddress && spec.mappingType != model.MappingTypeStakeCredential {
+		return false
+	}
+	if !resolvesInputs(spec.name) || len(utils.SpentInputs(msg.Tx)) == len(msg.ResolvedInputs) {
+		return false
+	}
+	slot, ok, err := h.storage.GetBackfillSlot(string(spec.mappingType), spec.mappingKey)
+	if err != nil {
+		h.logger.Error("failed to get backfill slot", zap.String("type", string(spec.mappingType)),
+			zap.String("key", spec.mappingKey), zap.Error(err))
+		return true
+	}
+	return !ok || msg.Block.Slot <= slot
+}
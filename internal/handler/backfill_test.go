@@ -0,0 +1,118 @@
+// internal/handler/backfill_test.go
+package handler
+
+import (
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/storage"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync/num"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+	"go.uber.org/zap"
+)
+
+const watchedAddress = "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8"
+
+type fakeLedger struct {
+	utxos []shared.Utxo
+	tip   uint64
+}
+
+func (f *fakeLedger) ChainTip(ctx context.Context) (chainsync.Point, error) {
+	return chainsync.PointStruct{Slot: f.tip, ID: "tip"}.Point(), nil
+}
+
+func (f *fakeLedger) UtxosByAddress(ctx context.Context, addresses ...string) ([]shared.Utxo, error) {
+	return f.utxos, nil
+}
+
+// backfillStorage records backfills. Other storage methods are not used.
+type backfillStorage struct {
+	storage.Storage
+	mappingID int
+	outputs   []model.TrackedOutput
+	slot      uint64
+	saved     bool
+}
+
+func (s *backfillStorage) SaveBackfill(mappingID int, outputs []model.TrackedOutput, slot uint64) error {
+	s.mappingID, s.outputs, s.slot, s.saved = mappingID, outputs, slot, true
+	return nil
+}
+
+func (s *backfillStorage) GetBackfillSlot(mappingType, key string) (uint64, bool, error) {
+	return s.slot, s.saved, nil
+}
+
+func TestBackfill(t *testing.T) {
+	ledger := &fakeLedger{
+		utxos: []shared.Utxo{{
+			Transaction: shared.UtxoTxID{ID: "aa"},
+			Index:       3,
+			Address:     watchedAddress,
+			Value:       shared.Value{shared.AdaPolicy: {shared.AdaAsset: num.Int64(5000000)}},
+			DatumHash:   "dd",
+		}},
+		tip: 1000,
+	}
+	store := &backfillStorage{}
+	h := &BlockHandler{storage: store, ledger: ledger, logger: zap.NewNop()}
+
+	if err := h.Backfill(context.Background(), model.Mapping{ID: 7, Type: model.MappingTypeAddress, Key: watchedAddress, Encoder: "DEFAULT"}); err != nil {
+		t.Fatal(err)
+	}
+	if store.saved {
+		t.Fatal("a mapping whose encoder does not resolve inputs was backfilled")
+	}
+
+	if err := h.Backfill(context.Background(), model.Mapping{ID: 7, Type: model.MappingTypeAddress, Key: watchedAddress, Encoder: "BALANCE"}); err != nil {
+		t.Fatal(err)
+	}
+	if store.mappingID != 7 || store.slot != 1000 || len(store.outputs) != 1 {
+		t.Fatalf("backfilled mapping %d at slot %d with %d outputs", store.mappingID, store.slot, len(store.outputs))
+	}
+	out := store.outputs[0]
+	if out.TxID != "aa" || out.Index != 3 || out.Address != watchedAddress || out.DatumHash != "dd" {
+		t.Errorf("tracked output is %+v", out)
+	}
+	var value shared.Value
+	if err := json.Unmarshal(out.Value, &value); err != nil || value.AdaLovelace().Int64() != 5000000 {
+		t.Errorf("tracked value is %s", out.Value)
+	}
+}
+
+func TestUnresolvedInputs(t *testing.T) {
+	store := &backfillStorage{}
+	h := &BlockHandler{storage: store, logger: zap.NewNop()}
+	balance := encoderSpec{name: "BALANCE", mappingType: model.MappingTypeAddress, mappingKey: watchedAddress}
+	msg := model.TxnMessage{
+		Tx:             chainsync.Tx{Inputs: []chainsync.TxIn{{Transaction: chainsync.TxInID{ID: "aa"}}, {Transaction: chainsync.TxInID{ID: "bb"}}}},
+		Block:          model.BlockDetails{Slot: 2000},
+		ResolvedInputs: []model.ResolvedInput{{Input: model.OutputRef{TxID: "aa"}, Address: watchedAddress}},
+	}
+
+	if !h.unresolvedInputs(balance, msg) {
+		t.Error("inputs of an address that was not backfilled are considered resolved")
+	}
+
+	store.slot, store.saved = 1000, true
+	if h.unresolvedInputs(balance, msg) {
+		t.Error("inputs of a block after the backfill are considered unresolved")
+	}
+	msg.Block.Slot = 1000
+	if !h.unresolvedInputs(balance, msg) {
+		t.Error("inputs of a block before the backfill are considered resolved")
+	}
+
+	if h.unresolvedInputs(encoderSpec{name: "NFT", mappingType: model.MappingTypePolicyID}, msg) {
+		t.Error("a policy mapping has unresolved inputs")
+	}
+	msg.Tx.Inputs = msg.Tx.Inputs[:1]
+	store.saved = false
+	if h.unresolvedInputs(balance, msg) {
+		t.Error("a transaction whose inputs are all resolved has unresolved inputs")
+	}
+}
@@ -0,0 +1,92 @@
+// internal/handler/deadletter.go
+package handler
+
+import (
+	"cardano-tx-sync/internal/encoder"
+	"cardano-tx-sync/internal/metrics"
+	"cardano-tx-sync/internal/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrMappingRemoved is returned when re-driving a dead letter whose mapping no longer exists.
+var ErrMappingRemoved = errors.New("the mapping of the dead letter was removed")
+
+// deadLetter stores a message that could not be encoded or published, and sends a copy to the
+// dead-letter topic if one is configured. Failures are logged, as there is nowhere else to go.
+func (h *BlockHandler) deadLetter(stage model.DeadLetterStage, msg *model.TxnMessage, mappingID int, encoderName, topic string, cause error) {
+	metrics.DeadLetters.WithLabelValues(string(stage), encoderName).Inc()
+
+	message, err := json.Marshal(msg)
+	if err != nil {
+		h.logger.Error("failed to encode dead letter", zap.String("tx_id", msg.Tx.ID), zap.Error(err))
+		return
+	}
+	entry := model.DeadLetter{
+		Stage:     stage,
+		TxID:      msg.Tx.ID,
+		Slot:      msg.Block.Slot,
+		BlockHash: msg.Block.Hash,
+		MappingID: &mappingID,
+		Encoder:   encoderName,
+		Topic:     topic,
+		Error:     cause.Error(),
+		Message:   message,
+		CreatedAt: time.Now().UTC(),
+	}
+	if entry.ID, err = h.storage.AddDeadLetter(entry); err != nil {
+		h.logger.Error("failed to store dead letter", zap.String("tx_id", entry.TxID), zap.Int("mapping_id", mappingID), zap.Error(err))
+	}
+
+	if h.deadLetterTopic == "" {
+		return
+	}
+	if err := h.producer.SendKeyedMessage(h.deadLetterTopic, entry.TxID, nil, entry); err != nil {
+		h.logger.Error("failed to send dead letter to kafka", zap.String("tx_id", entry.TxID), zap.Error(err))
+	}
+}
+
+// Redrive encodes a dead letter again with the current settings of its mapping and publishes it.
+// A message that the encoder now skips is not published, and the re-drive succeeds.
+func (h *BlockHandler) Redrive(entry model.DeadLetter) error {
+	if entry.MappingID == nil {
+		return ErrMappingRemoved
+	}
+	m, err := h.storage.GetMapping(*entry.MappingID)
+	if err != nil {
+		return fmt.Errorf("failed to get mapping: %w", err)
+	}
+	if m == nil {
+		return ErrMappingRemoved
+	}
+
+	var msg model.TxnMessage
+	if err := json.Unmarshal(entry.Message, &msg); err != nil {
+		return fmt.Errorf("invalid dead letter message: %w", err)
+	}
+
+	enc, err := h.mappingEncoder(*m)
+	if err != nil {
+		metrics.EncodeFailures.WithLabelValues(m.Encoder).Inc()
+		return fmt.Errorf("failed to create encoder: %w", err)
+	}
+	value, err := h.encode(enc, newEncoderSpec(*m), msg)
+	if errors.Is(err, encoder.ErrSkip) {
+		return nil
+	}
+	if err != nil {
+		metrics.EncodeFailures.WithLabelValues(m.Encoder).Inc()
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	key := partitionKey(*m, msg.Tx, msg.Block)
+	if err := h.producer.SendKeyedMessage(m.Topic, key, messageHeaders(m.Encoder, msg.Tx, msg.Block), value); err != nil {
+		return fmt.Errorf("failed to send message to kafka: %w", err)
+	}
+	metrics.MessagesPublished.WithLabelValues(m.Topic, m.Encoder).Inc()
+	return nil
+}
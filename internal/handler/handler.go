@@ -2,14 +2,27 @@
 package handler
 
 import (
+	"bytes"
+	"cardano-tx-sync/internal/address"
 	"cardano-tx-sync/internal/encoder"
 	"cardano-tx-sync/internal/kafka"
+	"cardano-tx-sync/internal/metrics"
 	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/plutus"
+	"cardano-tx-sync/internal/slottime"
 	"cardano-tx-sync/internal/storage"
+	"cardano-tx-sync/internal/utils"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/SundaeSwap-finance/ogmigo"
 	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
 	"go.uber.org/zap"
 )
 
@@ -18,14 +31,33 @@ type BlockHandler struct {
 	storage  storage.Storage
 	producer *kafka.Producer
 	logger   *zap.Logger
+	network  model.Network
+	clock    *slottime.Clock
+	ledger   ledgerState
+	// blockTopic is the topic of the block feed, empty if disabled.
+	blockTopic string
+	// deadLetterTopic receives a copy of every dead letter, empty if disabled.
+	deadLetterTopic string
+	// lastEra is the era of the last processed block. Blocks are processed sequentially.
+	lastEra string
+
+	// blueprints caches parsed blueprints by ID. Blueprints cannot be changed once uploaded.
+	blueprints sync.Map
+	// encoders caches the encoder built from each mapping's encoder options, by mapping ID.
+	encoders sync.Map
 }
 
-// NewBlockHandler creates a new BlockHandler.
-func NewBlockHandler(storage storage.Storage, producer *kafka.Producer, logger *zap.Logger) *BlockHandler {
+// NewBlockHandler creates a new BlockHandler. The Ogmios client is used to backfill tracked outputs.
+func NewBlockHandler(storage storage.Storage, producer *kafka.Producer, logger *zap.Logger, network model.Network, clock *slottime.Clock, ogmios *ogmigo.Client, blockTopic, deadLetterTopic string) *BlockHandler {
 	return &BlockHandler{
-		storage:  storage,
-		producer: producer,
-		logger:   logger,
+		storage:         storage,
+		producer:        producer,
+		logger:          logger,
+		network:         network,
+		clock:           clock,
+		ledger:          ogmios,
+		blockTopic:      blockTopic,
+		deadLetterTopic: deadLetterTopic,
 	}
 }
 
@@ -37,17 +69,65 @@ func (h *BlockHandler) HandleRollForward(block chainsync.Block, maxCheckpoints i
 		return nil // Continue
 	}
 
+	// Era summaries change at era boundaries, and block times must not be guessed across them.
+	if h.lastEra != "" && blockDetails.Era != h.lastEra {
+		if err := h.clock.Refresh(); err != nil {
+			h.logger.Warn("failed to refresh era summaries", zap.Error(err))
+		}
+	}
+	h.lastEra = blockDetails.Era
+	// An Ogmios timeout while refreshing the era summaries must not stall the sync. The block time
+	// is then extrapolated from the known eras, which is only wrong across an unforeseen era change.
+	slotTime, err := h.clock.BlockTime(blockDetails.Slot)
+	if err != nil {
+		h.logger.Warn("failed to convert block slot to time, extrapolating it",
+			zap.Uint64("slot", blockDetails.Slot), zap.Error(err))
+		slotTime = h.clock.Extrapolate(blockDetails.Slot)
+	}
+	blockDetails.Time, blockDetails.Epoch, blockDetails.EpochSlot = slotTime.Time, slotTime.Epoch, slotTime.EpochSlot
+
 	h.logger.Info("processing block", zap.Uint64("slot", blockDetails.Slot), zap.String("hash", blockDetails.Hash), zap.Int("tx_count", len(txs)))
 
+	// Inputs are resolved for the whole block before transactions are processed in parallel. A
+	// failure stops the sync, as the block would otherwise be published without them.
+	resolvedInputs, err := h.resolveInputs(txs, blockDetails.Slot)
+	if err != nil {
+		h.logger.Error("failed to resolve inputs", zap.Uint64("slot", blockDetails.Slot), zap.Error(err))
+		return err
+	}
+
+	// Transactions are matched and encoded in parallel, then published in block order so that
+	// messages with the same key reach their partition in chain order. The block's messages are
+	// sent as one batch, all acknowledged before the block is checkpointed.
+	messages := make([][]outgoingMessage, len(txs))
 	var wg sync.WaitGroup
-	for _, tx := range txs {
+	for i, tx := range txs {
 		wg.Add(1)
-		go func(tx chainsync.Tx) {
+		go func(tx chainsync.Tx, txIndex int) {
 			defer wg.Done()
-			h.processTx(tx, blockDetails)
-		}(tx)
+			messages[txIndex] = h.processTx(tx, txIndex, blockDetails, resolvedInputs[txIndex])
+		}(tx, i)
 	}
 	wg.Wait()
+	var batch []outgoingMessage
+	for _, txMessages := range messages {
+		batch = append(batch, txMessages...)
+	}
+	if err := h.publish(batch); err != nil {
+		h.logger.Error("failed to publish block messages", zap.Uint64("slot", blockDetails.Slot), zap.Error(err))
+		return err
+	}
+	metrics.BlocksProcessed.Inc()
+
+	// The block feed must not skip blocks, so the block is processed again if publishing fails.
+	if err := h.publishBlockEvent(model.BlockEvent{
+		Type:    model.BlockEventRollForward,
+		Network: h.network,
+		Block:   blockHeader(block, blockDetails),
+	}); err != nil {
+		h.logger.Error("failed to send block event to kafka", zap.Uint64("slot", blockDetails.Slot), zap.Error(err))
+		return err
+	}
 
 	// Save checkpoint
 	checkpoint := model.Checkpoint{
@@ -60,6 +140,12 @@ func (h *BlockHandler) HandleRollForward(block chainsync.Block, maxCheckpoints i
 		return err
 	}
 
+	if blockDetails.Slot > spentOutputRetention {
+		if err := h.storage.PruneSpentOutputs(blockDetails.Slot - spentOutputRetention); err != nil {
+			h.logger.Warn("failed to prune spent outputs", zap.Error(err))
+		}
+	}
+
 	return nil
 }
 
@@ -88,21 +174,174 @@ func (h *BlockHandler) HandleRollBackward(rb *chainsync.Point) error {
 			Slot: pointStruct.Slot,
 			Hash: pointStruct.ID,
 		},
+		Network: h.network,
 	}
 	if err := h.producer.SendMessage("cardano.rollbacks", rollbackMsg); err != nil {
 		h.logger.Error("failed to send rollback message to kafka", zap.Error(err))
 	}
 
+	if err := h.publishBlockEvent(model.BlockEvent{
+		Type:       model.BlockEventRollBackward,
+		Network:    h.network,
+		RollbackTo: &model.Checkpoint{Slot: pointStruct.Slot, Hash: pointStruct.ID},
+	}); err != nil {
+		h.logger.Error("failed to send rollback event to kafka", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// publishBlockEvent sends an event to the block feed, if enabled. All events are keyed by network
+// so that they land in a single partition, in chain order.
+func (h *BlockHandler) publishBlockEvent(event model.BlockEvent) error {
+	if h.blockTopic == "" {
+		return nil
+	}
+	headers := []kafka.Header{{Key: kafka.HeaderContentType, Value: "application/json"}}
+	if event.Block != nil {
+		headers = append(headers,
+			kafka.Header{Key: kafka.HeaderSlot, Value: strconv.FormatUint(event.Block.Slot, 10)},
+			kafka.Header{Key: kafka.HeaderBlockHash, Value: event.Block.Hash})
+	}
+	if err := h.producer.SendKeyedMessage(h.blockTopic, h.network.Name, headers, event); err != nil {
+		return err
+	}
+	metrics.MessagesPublished.WithLabelValues(h.blockTopic, "BLOCK").Inc()
 	return nil
 }
 
-func (h *BlockHandler) processTx(tx chainsync.Tx, blockDetails model.BlockDetails) {
-	// topicsByEncoder groups topics by the required encoder name.
-	// map[encoderName]map[topicName]struct{}
-	topicsByEncoder := make(map[string]map[string]struct{})
+// blockHeader summarizes a block. Failed transactions contribute their collateral to the fees and
+// their collateral return to the outputs.
+func blockHeader(block chainsync.Block, details model.BlockDetails) *model.BlockHeader {
+	header := &model.BlockHeader{BlockDetails: details, PrevHash: block.Ancestor}
+	for _, tx := range block.Transactions {
+		fee := tx.Fee
+		if utils.IsInvalidated(tx) && tx.TotalCollateral != nil {
+			fee = *tx.TotalCollateral
+		}
+		header.TotalFees += fee.AdaLovelace().Uint64()
+		for _, out := range utils.ProducedOutputs(tx) {
+			header.TotalOutput += out.Value.AdaLovelace().Uint64()
+		}
+	}
+	return header
+}
+
+// encoderSpec identifies an encoder configured with a mapping's encoder options. The mapping type
+// and key are only set for per-mapping encoders, which encode a message for each matched mapping.
+type encoderSpec struct {
+	name        string
+	options     string
+	mappingType model.MappingType
+	mappingKey  string
+	blueprintID int
+}
 
-	// addMapping finds all relevant mappings and groups their topics by encoder.
-	addMapping := func(mappingType model.MappingType, key string) {
+// publishTarget is a topic and the key its messages are published with.
+type publishTarget struct {
+	topic string
+	key   string
+}
+
+// outgoingMessage is an encoded message waiting to be published.
+type outgoingMessage struct {
+	publishTarget
+	txID    string
+	encoder string
+	headers []kafka.Header
+	value   []byte
+
+	// message and mappingID are what the message was encoded from, kept for dead letters.
+	message   *model.TxnMessage
+	mappingID int
+}
+
+// partitionKey returns the Kafka message key of a mapping's message for a transaction.
+func partitionKey(m model.Mapping, tx chainsync.Tx, block model.BlockDetails) string {
+	switch m.PartitionKey {
+	case model.PartitionKeyMappingKey:
+		return m.Key
+	case model.PartitionKeyTopic:
+		return m.Topic
+	case model.PartitionKeyTxID:
+		return tx.ID
+	case model.PartitionKeyBlockHash:
+		return block.Hash
+	case model.PartitionKeyAddress:
+		return matchedAddress(m, tx)
+	case model.PartitionKeyPolicyID:
+		if m.Type == model.MappingTypePolicyID && m.Key != "*" {
+			return m.Key
+		}
+		for _, value := range append([]shared.Value{tx.Mint}, outputValues(tx)...) {
+			if policies := utils.SortedPolicies(value); len(policies) > 0 {
+				return policies[0]
+			}
+		}
+		return ""
+	case model.PartitionKeyStakeCredential:
+		if m.Type == model.MappingTypeStakeCredential {
+			return m.Key
+		}
+		stakeHash, _ := address.StakeCredentialHash(matchedAddress(m, tx))
+		return stakeHash
+	default:
+		return ""
+	}
+}
+
+// matchedAddress returns the address matched by an address mapping, or the first output address
+// for other mappings.
+func matchedAddress(m model.Mapping, tx chainsync.Tx) string {
+	if m.Type == model.MappingTypeAddress && m.Key != "*" {
+		return m.Key
+	}
+	if len(tx.Outputs) > 0 {
+		return tx.Outputs[0].Address
+	}
+	return ""
+}
+
+func outputValues(tx chainsync.Tx) []shared.Value {
+	values := make([]shared.Value, len(tx.Outputs))
+	for i, out := range tx.Outputs {
+		values[i] = out.Value
+	}
+	return values
+}
+
+// messageHeaders returns the standard headers of a transaction message.
+func messageHeaders(encoderName string, tx chainsync.Tx, block model.BlockDetails) []kafka.Header {
+	headers := []kafka.Header{
+		{Key: kafka.HeaderEncoder, Value: encoderName},
+		{Key: kafka.HeaderSlot, Value: strconv.FormatUint(block.Slot, 10)},
+		{Key: kafka.HeaderBlockHash, Value: block.Hash},
+		{Key: kafka.HeaderTxID, Value: tx.ID},
+	}
+	if info, ok := encoder.Lookup(encoderName); ok {
+		headers = append(headers,
+			kafka.Header{Key: kafka.HeaderContentType, Value: info.ContentType},
+			kafka.Header{Key: kafka.HeaderSchemaVersion, Value: info.SchemaVersion})
+	}
+	return headers
+}
+
+// processTx matches a transaction against the mappings and encodes its messages. A transaction
+// matched by several mappings is encoded once per encoder and published once per topic and key.
+func (h *BlockHandler) processTx(tx chainsync.Tx, txIndex int, blockDetails model.BlockDetails, resolvedInputs []model.ResolvedInput) []outgoingMessage {
+	// topicsByEncoder groups publish targets by the required encoder and its options, with the
+	// lowest ID of the mappings sharing each target.
+	topicsByEncoder := make(map[encoderSpec]map[publishTarget]int)
+	// encoders holds the encoder of each spec, taken from the first mapping requiring it. Mappings
+	// with the same spec have equivalent encoders.
+	encoders := make(map[encoderSpec]builtEncoder)
+	// matchedTypes records which mapping types matched, for metrics.
+	matchedTypes := make(map[model.MappingType]struct{})
+
+	// addMapping finds all relevant mappings and groups their topics by encoder. When spending,
+	// address mappings only match if their encoder resolves inputs.
+	addMapping := func(mappingType model.MappingType, key string, spending bool) {
 		mappings, err := h.storage.GetMappingsFor(string(mappingType), key)
 		if err != nil {
 			h.logger.Error("failed to get mappings",
@@ -112,29 +351,57 @@ func (h *BlockHandler) processTx(tx chainsync.Tx, blockDetails model.BlockDetail
 			return
 		}
 		for _, m := range mappings {
-			if _, ok := topicsByEncoder[m.Encoder]; !ok {
-				topicsByEncoder[m.Encoder] = make(map[string]struct{})
+			if spending && m.Type == model.MappingTypeAddress && !resolvesInputs(m.Encoder) {
+				continue
+			}
+			matchedTypes[mappingType] = struct{}{}
+			spec := newEncoderSpec(m)
+			if _, ok := topicsByEncoder[spec]; !ok {
+				topicsByEncoder[spec] = make(map[publishTarget]int)
+				enc, err := h.mappingEncoder(m)
+				encoders[spec] = builtEncoder{enc: enc, err: err}
+			}
+			target := publishTarget{topic: m.Topic, key: partitionKey(m, tx, blockDetails)}
+			if id, ok := topicsByEncoder[spec][target]; !ok || m.ID < id {
+				topicsByEncoder[spec][target] = m.ID
 			}
-			topicsByEncoder[m.Encoder][m.Topic] = struct{}{}
 		}
 	}
 
 	// 1. Address and Policy ID mappings
-	addMapping(model.MappingTypeAddress, "*")
+	addMapping(model.MappingTypeAddress, "*", false)
 
 	for _, output := range tx.Outputs {
-		// Check for address mappings
-		addMapping(model.MappingTypeAddress, output.Address)
+		// Check for address and stake credential mappings
+		addMapping(model.MappingTypeAddress, output.Address, false)
+		if stakeHash, ok := address.StakeCredentialHash(output.Address); ok {
+			addMapping(model.MappingTypeStakeCredential, stakeHash, false)
+		}
 
 		// Check for policy ID mappings
 		for policyID, _ := range output.Value {
-			addMapping(model.MappingTypePolicyID, policyID)
+			addMapping(model.MappingTypePolicyID, policyID, false)
+		}
+	}
+
+	// Spent outputs of watched addresses and stake credentials
+	for _, input := range resolvedInputs {
+		addMapping(model.MappingTypeAddress, input.Address, true)
+		if stakeHash, ok := address.StakeCredentialHash(input.Address); ok {
+			addMapping(model.MappingTypeStakeCredential, stakeHash, true)
+		}
+	}
+
+	// Minted and burned policies, including burns that leave nothing in the outputs
+	for policyID := range tx.Mint {
+		if policyID != shared.AdaPolicy {
+			addMapping(model.MappingTypePolicyID, policyID, false)
 		}
 	}
 
 	// 2. Certificate mappings
 	if len(tx.Certificates) > 0 {
-		addMapping(model.MappingTypeCert, "*") // For any certificate
+		addMapping(model.MappingTypeCert, "*", false) // For any certificate
 
 		for _, cert := range tx.Certificates {
 			var c map[string]interface{}
@@ -143,7 +410,7 @@ func (h *BlockHandler) processTx(tx chainsync.Tx, blockDetails model.BlockDetail
 				continue
 			}
 			if certType, ok := c["type"].(string); ok {
-				addMapping(model.MappingTypeCert, certType)
+				addMapping(model.MappingTypeCert, certType, false)
 			} else {
 				h.logger.Warn("certificate 'type' field is missing or not a string", zap.Any("certificate", cert))
 			}
@@ -152,53 +419,263 @@ func (h *BlockHandler) processTx(tx chainsync.Tx, blockDetails model.BlockDetail
 
 	// 3. Proposal mapping
 	if len(tx.Proposals) > 0 {
-		addMapping(model.MappingTypeProposal, "*")
+		addMapping(model.MappingTypeProposal, "*", false)
 	}
 
 	// 4. Vote mapping
 	if len(tx.Votes) > 0 {
-		addMapping(model.MappingTypeVote, "*")
+		addMapping(model.MappingTypeVote, "*", false)
+	}
+
+	for mappingType := range matchedTypes {
+		metrics.TxsMatched.WithLabelValues(string(mappingType)).Inc()
 	}
 
-	// If any mappings were matched, encode and send the message.
+	// If any mappings were matched, encode the message.
+	var messages []outgoingMessage
 	if len(topicsByEncoder) > 0 {
-		txnMsg := model.TxnMessage{Tx: tx, Block: blockDetails}
-		for encoderName, topics := range topicsByEncoder {
-			// Get the appropriate encoder
-			enc, err := encoder.GetEncoder(encoderName)
-			if err != nil {
-				h.logger.Error("could not find encoder", zap.String("encoder", encoderName), zap.Error(err))
+		txnMsg := model.TxnMessage{
+			Tx:             tx,
+			TxIndex:        txIndex,
+			Block:          blockDetails,
+			Network:        h.network,
+			Invalidated:    utils.IsInvalidated(tx),
+			ValidFrom:      h.slotTime(tx.ValidityInterval.InvalidBefore),
+			ValidUntil:     h.slotTime(tx.ValidityInterval.InvalidAfter),
+			ResolvedInputs: resolvedInputs,
+		}
+		for spec, topics := range topicsByEncoder {
+			built := encoders[spec]
+			var encodedMsg []byte
+			err := built.err
+			if err == nil {
+				encodedMsg, err = h.encode(built.enc, spec, txnMsg)
+			}
+			if errors.Is(err, encoder.ErrSkip) {
 				continue
 			}
-
-			// Encode the message
-			encodedMsg, err := enc.Encode(txnMsg)
 			if err != nil {
-				h.logger.Error("failed to encode message", zap.String("encoder", encoderName), zap.Error(err))
+				h.logger.Error("failed to encode message", zap.String("encoder", spec.name), zap.String("tx_id", tx.ID), zap.Error(err))
+				metrics.EncodeFailures.WithLabelValues(spec.name).Inc()
+				for target, mappingID := range topics {
+					h.deadLetter(model.DeadLetterStageEncode, &txnMsg, mappingID, spec.name, target.topic, err)
+				}
 				continue
 			}
 
-			// Send to all topics for this encoder
-			for topic := range topics {
-				if err := h.producer.SendMessage(topic, encodedMsg); err != nil {
-					h.logger.Error("failed to send message to kafka",
-						zap.Error(err),
-						zap.String("topic", topic),
-						zap.String("encoder", encoderName),
-						zap.String("tx_id", tx.ID))
-				}
+			headers := messageHeaders(spec.name, tx, blockDetails)
+			for target, mappingID := range topics {
+				messages = append(messages, outgoingMessage{
+					publishTarget: target,
+					txID:          tx.ID,
+					encoder:       spec.name,
+					headers:       headers,
+					value:         encodedMsg,
+					message:       &txnMsg,
+					mappingID:     mappingID,
+				})
 			}
 		}
 	}
+
+	// Encoders are iterated in random order; sort so that a transaction's messages to the same
+	// partition are always published in the same order.
+	sort.Slice(messages, func(i, j int) bool {
+		a, b := messages[i], messages[j]
+		if a.topic != b.topic {
+			return a.topic < b.topic
+		}
+		if a.key != b.key {
+			return a.key < b.key
+		}
+		if a.encoder != b.encoder {
+			return a.encoder < b.encoder
+		}
+		return bytes.Compare(a.value, b.value) < 0
+	})
+	return messages
+}
+
+// publish sends messages in order and waits for all of them to be acknowledged. Messages rejected
+// for what they are, such as their size, are dead-lettered. Any other failure, such as unreachable
+// brokers, returns an error without dead-lettering anything, so that the block is not checkpointed
+// and is published again: consumers may see messages of the block twice, but never a gap.
+func (h *BlockHandler) publish(messages []outgoingMessage) error {
+	batch := make([]kafka.Message, len(messages))
+	for i, msg := range messages {
+		batch[i] = kafka.Message{Topic: msg.topic, Key: msg.key, Headers: msg.headers, Value: msg.value}
+	}
+	errs := h.producer.SendBatch(batch)
+
+	failed := 0
+	var brokerErr error
+	for i, err := range errs {
+		if err == nil {
+			metrics.MessagesPublished.WithLabelValues(messages[i].topic, messages[i].encoder).Inc()
+			continue
+		}
+		failed++
+		if brokerErr == nil && !kafka.IsMessageError(err) {
+			brokerErr = err
+		}
+	}
+	if brokerErr != nil {
+		return fmt.Errorf("failed to send %d of %d messages to kafka: %w", failed, len(messages), brokerErr)
+	}
+	for i, err := range errs {
+		if err != nil {
+			h.publishFailed(messages[i], err)
+		}
+	}
+	return nil
+}
+
+// publishFailed reports a message that could not be published and dead-letters it.
+func (h *BlockHandler) publishFailed(msg outgoingMessage, err error) {
+	h.logger.Error("failed to send message to kafka",
+		zap.Error(err),
+		zap.String("topic", msg.topic),
+		zap.String("encoder", msg.encoder),
+		zap.String("tx_id", msg.txID))
+	h.deadLetter(model.DeadLetterStagePublish, msg.message, msg.mappingID, msg.encoder, msg.topic, err)
+}
+
+// newEncoderSpec returns the encoder spec of a mapping.
+func newEncoderSpec(m model.Mapping) encoderSpec {
+	spec := encoderSpec{name: m.Encoder, options: string(m.EncoderOptions)}
+	if m.BlueprintID != nil {
+		spec.blueprintID = *m.BlueprintID
+	}
+	if info, ok := encoder.Lookup(m.Encoder); ok && info.PerMapping {
+		spec.mappingType, spec.mappingKey = m.Type, m.Key
+	}
+	return spec
+}
+
+// builtEncoder is the encoder of a mapping, or the error building it from the mapping's options.
+type builtEncoder struct {
+	enc encoder.Encoder
+	err error
+}
+
+// cachedEncoder is an encoder with the options it was built from.
+type cachedEncoder struct {
+	name    string
+	options string
+	enc     encoder.Encoder
+}
+
+// mappingEncoder returns the encoder of a mapping, built once from its encoder options and then
+// reused. The encoder is rebuilt if the mapping's encoder or options no longer match.
+func (h *BlockHandler) mappingEncoder(m model.Mapping) (encoder.Encoder, error) {
+	if cached, ok := h.encoders.Load(m.ID); ok {
+		c := cached.(cachedEncoder)
+		if c.name == m.Encoder && c.options == string(m.EncoderOptions) {
+			return c.enc, nil
+		}
+	}
+	enc, err := encoder.New(m.Encoder, m.EncoderOptions)
+	if err != nil {
+		return nil, err
+	}
+	h.encoders.Store(m.ID, cachedEncoder{name: m.Encoder, options: string(m.EncoderOptions), enc: enc})
+	return enc, nil
+}
+
+// ForgetMapping drops the cached encoder of a removed mapping.
+func (h *BlockHandler) ForgetMapping(id int) {
+	h.encoders.Delete(id)
+}
+
+// encode encodes a transaction message with the encoder of a spec. It returns encoder.ErrSkip when
+// the encoder has nothing to publish. A panicking encoder returns an error, so that the message is
+// dead-lettered instead of stopping the sync.
+func (h *BlockHandler) encode(enc encoder.Encoder, spec encoderSpec, msg model.TxnMessage) (value []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.Error("encoder panicked", zap.String("encoder", spec.name), zap.String("tx_id", msg.Tx.ID),
+				zap.Any("panic", r), zap.Stack("stack"))
+			value, err = nil, fmt.Errorf("encoder %s panicked: %v", spec.name, r)
+		}
+	}()
+	if spec.blueprintID != 0 {
+		msg.TypedData = h.typedData(msg.Tx, spec.blueprintID)
+	}
+	msg.UnresolvedInputs = h.unresolvedInputs(spec, msg)
+	// Inputs are resolved for every watched entity at once. Encoders that do not use them would
+	// publish the spent outputs watched by other mappings to their topic.
+	if !resolvesInputs(spec.name) {
+		msg.ResolvedInputs = nil
+	}
+	if mappingEnc, ok := enc.(encoder.MappingEncoder); ok && spec.mappingType != "" {
+		return mappingEnc.EncodeForMapping(msg, model.Mapping{Type: spec.mappingType, Key: spec.mappingKey})
+	}
+	return enc.Encode(msg)
+}
+
+// slotTime converts a validity interval bound. Zero means the bound is not set.
+func (h *BlockHandler) slotTime(slot uint64) *time.Time {
+	if slot == 0 {
+		return nil
+	}
+	t := h.clock.Time(slot)
+	return &t
+}
+
+// typedData decodes the datums and redeemers of a transaction with a blueprint. Failures are logged
+// and the message is published without typed data.
+func (h *BlockHandler) typedData(tx chainsync.Tx, blueprintID int) json.RawMessage {
+	blueprint, err := h.blueprint(blueprintID)
+	if err != nil {
+		h.logger.Error("failed to load blueprint", zap.Int("blueprint_id", blueprintID), zap.Error(err))
+		return nil
+	}
+	typed, err := json.Marshal(blueprint.DecodeTx(tx))
+	if err != nil {
+		h.logger.Error("failed to encode typed data", zap.Int("blueprint_id", blueprintID), zap.Error(err))
+		return nil
+	}
+	return typed
+}
+
+func (h *BlockHandler) blueprint(id int) (*plutus.Blueprint, error) {
+	if cached, ok := h.blueprints.Load(id); ok {
+		return cached.(*plutus.Blueprint), nil
+	}
+	stored, err := h.storage.GetBlueprint(id)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, fmt.Errorf("blueprint %d not found", id)
+	}
+	parsed, err := plutus.ParseBlueprint(stored.Blueprint)
+	if err != nil {
+		return nil, err
+	}
+	h.blueprints.Store(id, parsed)
+	return parsed, nil
 }
 
 func (h *BlockHandler) parseBlock(block chainsync.Block) (model.BlockDetails, []chainsync.Tx, error) {
 	// Note: The `chainsync.Block` struct in ogmigo actually has `ID` for hash and `Slot` for slot.
 	// The `Transactions` field holds the list of transactions.
 	blockDetails := model.BlockDetails{
-		Hash: block.ID,
-		Slot: block.Slot,
-		Era:  block.Era, // This will be "Alonzo", "Babbage", etc., or empty if not set.
+		Hash:    block.ID,
+		Slot:    block.Slot,
+		Era:     block.Era, // This will be "Alonzo", "Babbage", etc., or empty if not set.
+		Height:  block.Height,
+		Size:    block.Size.Bytes,
+		TxCount: len(block.Transactions),
+	}
+
+	// Byron blocks are issued by genesis delegates rather than stake pools.
+	if block.Type == "praos" && block.Issuer.VerificationKey != "" {
+		poolID, err := address.PoolID(block.Issuer.VerificationKey)
+		if err != nil {
+			h.logger.Warn("invalid block issuer", zap.String("hash", block.ID), zap.Error(err))
+		}
+		blockDetails.IssuerPoolID = poolID
 	}
 	return blockDetails, block.Transactions, nil
 }
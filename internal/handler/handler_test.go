@@ -0,0 +1,115 @@
+// internal/handler/handler_test.go
+package handler
+
+import (
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/storage"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"go.uber.org/zap"
+)
+
+func TestMappingEncoderIsReused(t *testing.T) {
+	h := &BlockHandler{}
+	m := model.Mapping{ID: 1, Encoder: "PROJECTION", EncoderOptions: json.RawMessage(`{"fields": ["tx_id"]}`)}
+
+	first, err := h.mappingEncoder(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := h.mappingEncoder(m); again != first {
+		t.Error("the encoder was built again for an unchanged mapping")
+	}
+
+	changed := m
+	changed.EncoderOptions = json.RawMessage(`{"fields": ["tx_id", "fee"]}`)
+	rebuilt, err := h.mappingEncoder(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rebuilt == first {
+		t.Error("the encoder was reused after the mapping's options changed")
+	}
+
+	h.ForgetMapping(m.ID)
+	if _, ok := h.encoders.Load(m.ID); ok {
+		t.Error("the encoder of a removed mapping is still cached")
+	}
+
+	if _, err := h.mappingEncoder(model.Mapping{ID: 2, Encoder: "PROJECTION"}); err == nil {
+		t.Error("invalid options were accepted")
+	}
+	if _, ok := h.encoders.Load(2); ok {
+		t.Error("an encoder that failed to build was cached")
+	}
+}
+
+type panickingEncoder struct{}
+
+func (panickingEncoder) Encode(model.TxnMessage) ([]byte, error) {
+	var m map[string]int
+	m["boom"]++
+	return nil, nil
+}
+
+func TestEncodeRecoversPanics(t *testing.T) {
+	h := &BlockHandler{logger: zap.NewNop()}
+	value, err := h.encode(panickingEncoder{}, encoderSpec{name: "BROKEN"}, model.TxnMessage{})
+	if err == nil || !strings.Contains(err.Error(), "encoder BROKEN panicked") {
+		t.Errorf("error = %v, want the panic as an error", err)
+	}
+	if value != nil {
+		t.Errorf("value = %q after a panic", value)
+	}
+}
+
+// recordingEncoder keeps the last message it encoded.
+type recordingEncoder struct {
+	msg model.TxnMessage
+}
+
+func (e *recordingEncoder) Encode(msg model.TxnMessage) ([]byte, error) {
+	e.msg = msg
+	return []byte("{}"), nil
+}
+
+func TestEncodeGivesResolvedInputsOnlyToEncodersResolvingThem(t *testing.T) {
+	h := &BlockHandler{logger: zap.NewNop()}
+	msg := model.TxnMessage{ResolvedInputs: []model.ResolvedInput{{Input: model.OutputRef{TxID: "aa"}, Address: watchedAddress}}}
+
+	enc := &recordingEncoder{}
+	if _, err := h.encode(enc, encoderSpec{name: "DEFAULT"}, msg); err != nil {
+		t.Fatal(err)
+	}
+	if len(enc.msg.ResolvedInputs) != 0 {
+		t.Errorf("DEFAULT was given resolved inputs %+v", enc.msg.ResolvedInputs)
+	}
+
+	if _, err := h.encode(enc, encoderSpec{name: "BALANCE"}, msg); err != nil {
+		t.Fatal(err)
+	}
+	if len(enc.msg.ResolvedInputs) != 1 {
+		t.Errorf("BALANCE was given resolved inputs %+v", enc.msg.ResolvedInputs)
+	}
+}
+
+// failingMappingsStorage fails to read mappings. Other storage methods are not used.
+type failingMappingsStorage struct {
+	storage.Storage
+}
+
+func (failingMappingsStorage) GetMappingsFor(mappingType, key string) ([]model.Mapping, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestResolveInputsFailsWithoutMappings(t *testing.T) {
+	h := &BlockHandler{storage: failingMappingsStorage{}, logger: zap.NewNop()}
+	txs := []chainsync.Tx{{ID: "aa", Outputs: chainsync.TxOuts{{Address: watchedAddress}}}}
+	if _, err := h.resolveInputs(txs, 1000); err == nil {
+		t.Error("outputs were left untracked when the mappings could not be read")
+	}
+}
@@ -0,0 +1,155 @@
+// internal/handler/inputs.go
+package handler
+
+import (
+	"cardano-tx-sync/internal/address"
+	"cardano-tx-sync/internal/encoder"
+	"cardano-tx-sync/internal/model"
+	"cardano-tx-sync/internal/plutus"
+	"cardano-tx-sync/internal/utils"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync/num"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+)
+
+// spentOutputRetention is how long tracked outputs are kept after being spent, in slots. It covers
+// the longest possible rollback (3k/f slots) so that re-applied blocks still resolve their inputs.
+const spentOutputRetention = 129600
+
+// resolveInputs tracks the outputs of a block that belong to watched addresses and stake
+// credentials or hold CIP-68 reference tokens of watched policies, and resolves the inputs spending
+// tracked outputs. The outputs tracked in the block are only stored once the whole block is
+// resolved, so they are collected in created, and an input spending one of them is resolved from
+// there rather than from storage. It returns the resolved inputs of each transaction, by index in
+// the block.
+func (h *BlockHandler) resolveInputs(txs []chainsync.Tx, slot uint64) (map[int][]model.ResolvedInput, error) {
+	created := make(map[model.OutputRef]model.TrackedOutput)
+	var createdOrder []model.TrackedOutput
+	var refs []model.OutputRef
+	for _, tx := range txs {
+		for _, in := range utils.SpentInputs(tx) {
+			ref := model.OutputRef{TxID: in.Transaction.ID, Index: in.Index}
+			if _, ok := created[ref]; !ok {
+				refs = append(refs, ref)
+			}
+		}
+		for index, out := range utils.ProducedOutputs(tx) {
+			isTracked, err := h.isTracked(out)
+			if err != nil {
+				return nil, err
+			}
+			if !isTracked {
+				continue
+			}
+			value, err := json.Marshal(out.Value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode value of %s#%d: %w", tx.ID, index, err)
+			}
+			tracked := model.TrackedOutput{
+				OutputRef: model.OutputRef{TxID: tx.ID, Index: index},
+				Address:   out.Address,
+				Value:     value,
+				DatumHash: plutus.DatumHash(out),
+				Slot:      slot,
+			}
+			created[tracked.OutputRef] = tracked
+			createdOrder = append(createdOrder, tracked)
+		}
+	}
+
+	stored, err := h.storage.ResolveOutputs(refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve inputs: %w", err)
+	}
+	known := make(map[model.OutputRef]model.TrackedOutput, len(stored)+len(created))
+	for _, out := range stored {
+		known[out.OutputRef] = out
+	}
+	for ref, out := range created {
+		known[ref] = out
+	}
+
+	resolved := make(map[int][]model.ResolvedInput)
+	var spent []model.OutputRef
+	for i, tx := range txs {
+		for _, in := range utils.SpentInputs(tx) {
+			ref := model.OutputRef{TxID: in.Transaction.ID, Index: in.Index}
+			out, ok := known[ref]
+			if !ok {
+				continue
+			}
+			var value shared.Value
+			if err := json.Unmarshal(out.Value, &value); err != nil {
+				return nil, fmt.Errorf("failed to decode value of %s#%d: %w", ref.TxID, ref.Index, err)
+			}
+			resolved[i] = append(resolved[i], model.ResolvedInput{Input: ref, Address: out.Address, Value: value, DatumHash: out.DatumHash})
+			spent = append(spent, ref)
+		}
+	}
+
+	if err := h.storage.SaveBlockOutputs(createdOrder, spent, slot); err != nil {
+		return nil, fmt.Errorf("failed to save tracked outputs: %w", err)
+	}
+	return resolved, nil
+}
+
+// isTracked tells whether an output must be tracked: the stake credential of its address is
+// watched, the address itself is watched by a mapping whose encoder resolves inputs, or it holds a
+// CIP-68 reference token of a policy watched by such a mapping. Mappings that cannot be read fail the
+// block: an output that is not tracked could never be resolved once spent.
+func (h *BlockHandler) isTracked(out chainsync.TxOut) (bool, error) {
+	addr := out.Address
+	if stakeHash, ok := address.StakeCredentialHash(addr); ok {
+		mappings, err := h.storage.GetMappingsFor(string(model.MappingTypeStakeCredential), stakeHash)
+		if err != nil {
+			return false, fmt.Errorf("failed to get mappings of stake credential %s: %w", stakeHash, err)
+		}
+		if len(mappings) > 0 {
+			return true, nil
+		}
+	}
+	mappings, err := h.storage.GetMappingsFor(string(model.MappingTypeAddress), addr)
+	if err != nil {
+		return false, fmt.Errorf("failed to get mappings of address %s: %w", addr, err)
+	}
+	for _, m := range mappings {
+		if resolvesInputs(m.Encoder) {
+			return true, nil
+		}
+	}
+	for policyID, assets := range out.Value {
+		if !holdsReferenceToken(assets) {
+			continue
+		}
+		for _, key := range []string{policyID, "*"} {
+			mappings, err := h.storage.GetMappingsFor(string(model.MappingTypePolicyID), key)
+			if err != nil {
+				return false, fmt.Errorf("failed to get mappings of policy %s: %w", key, err)
+			}
+			for _, m := range mappings {
+				if resolvesInputs(m.Encoder) {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+func holdsReferenceToken(assets map[string]num.Int) bool {
+	for assetName := range assets {
+		if strings.HasPrefix(assetName, encoder.CIP68ReferencePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func resolvesInputs(encoderName string) bool {
+	info, ok := encoder.Lookup(encoderName)
+	return ok && info.ResolvesInputs
+}
@@ -0,0 +1,120 @@
+// internal/kafka/config.go
+package kafka
+
+import (
+	"cardano-tx-sync/config"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/IBM/sarama"
+)
+
+// newSaramaConfig builds the Sarama configuration of the producer from the application settings.
+func newSaramaConfig(cfg config.KafkaConfig) (*sarama.Config, error) {
+	c := sarama.NewConfig()
+	c.ClientID = cfg.ClientID
+	if cfg.Version != "" {
+		version, err := sarama.ParseKafkaVersion(cfg.Version)
+		if err != nil {
+			return nil, err
+		}
+		c.Version = version
+	}
+
+	c.Producer.Return.Successes = true
+	switch cfg.RequiredAcks {
+	case "leader":
+		c.Producer.RequiredAcks = sarama.WaitForLocal
+	case "none":
+		c.Producer.RequiredAcks = sarama.NoResponse
+	default:
+		c.Producer.RequiredAcks = sarama.WaitForAll
+	}
+	c.Producer.Retry.Max = cfg.MaxRetries
+	if cfg.Async {
+		// With several requests in flight per broker, a retried request can land after a later
+		// one and reorder messages of the same key.
+		c.Net.MaxOpenRequests = 1
+	}
+	if cfg.Idempotent {
+		// Idempotence needs at most one in-flight request per broker and Kafka 0.11+.
+		c.Producer.Idempotent = true
+		c.Net.MaxOpenRequests = 1
+		if !c.Version.IsAtLeast(sarama.V0_11_0_0) {
+			return nil, fmt.Errorf("the idempotent producer requires kafka version 0.11 or later, got %s", c.Version)
+		}
+	}
+
+	if cfg.Compression != "" {
+		if err := c.Producer.Compression.UnmarshalText([]byte(cfg.Compression)); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.MaxMessageBytes > 0 {
+		c.Producer.MaxMessageBytes = cfg.MaxMessageBytes
+	}
+	c.Producer.Flush.Frequency = cfg.Linger
+	c.Producer.Flush.Messages = cfg.BatchSize
+	c.Producer.Flush.Bytes = cfg.BatchBytes
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		c.Net.TLS.Enable = true
+		c.Net.TLS.Config = tlsConfig
+	}
+
+	if cfg.SASL.Mechanism != "" {
+		c.Net.SASL.Enable = true
+		c.Net.SASL.User = cfg.SASL.Username
+		c.Net.SASL.Password = cfg.SASL.Password
+		switch cfg.SASL.Mechanism {
+		case sarama.SASLTypePlaintext:
+			c.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		case sarama.SASLTypeSCRAMSHA256:
+			c.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+			c.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &scramClient{hashGenerator: sha256Generator} }
+		case sarama.SASLTypeSCRAMSHA512:
+			c.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+			c.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &scramClient{hashGenerator: sha512Generator} }
+		default:
+			return nil, fmt.Errorf("unknown SASL mechanism %q", cfg.SASL.Mechanism)
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid kafka configuration: %w", err)
+	}
+	return c, nil
+}
+
+// newTLSConfig loads the CA and client certificate files of the Kafka connection.
+func newTLSConfig(cfg config.KafkaTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kafka CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in kafka CA file %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kafka client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -2,56 +2,247 @@
 package kafka
 
 import (
+	"cardano-tx-sync/config"
+	"cardano-tx-sync/internal/metrics"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 )
 
-// Producer wraps a Sarama SyncProducer.
+// Producer wraps a Sarama SyncProducer, or an AsyncProducer in async mode.
 type Producer struct {
+	client   sarama.Client
 	producer sarama.SyncProducer
+	headers  []sarama.RecordHeader
+
+	// maxMessageBytes and recordVersion size messages as the producer does before sending them.
+	maxMessageBytes int
+	recordVersion   int
+
+	// async is set in async mode. Its results are read by one batch at a time, under batchMu.
+	async   sarama.AsyncProducer
+	batchMu sync.Mutex
 }
 
-// NewProducer creates a new Kafka producer.
-func NewProducer(brokers []string) (*Producer, error) {
-	config := sarama.NewConfig()
-	config.Producer.Return.Successes = true
-	config.Producer.RequiredAcks = sarama.WaitForAll
-	config.Producer.Retry.Max = 5
+// NewProducer creates a new Kafka producer. The given headers are attached to every message.
+func NewProducer(cfg config.KafkaConfig, headers map[string]string) (*Producer, error) {
+	saramaConfig, err := newSaramaConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	producer, err := sarama.NewSyncProducer(brokers, config)
+	// Keep a handle on the underlying client so that broker health can be checked.
+	client, err := sarama.NewClient(cfg.Brokers, saramaConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Producer{producer: producer}, nil
+	p := &Producer{client: client, maxMessageBytes: saramaConfig.Producer.MaxMessageBytes, recordVersion: 1}
+	if saramaConfig.Version.IsAtLeast(sarama.V0_11_0_0) {
+		p.recordVersion = 2
+	}
+	if cfg.Async {
+		p.async, err = sarama.NewAsyncProducerFromClient(client)
+	} else {
+		p.producer, err = sarama.NewSyncProducerFromClient(client)
+	}
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	p.headers = make([]sarama.RecordHeader, 0, len(headers))
+	for key, value := range headers {
+		p.headers = append(p.headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	}
+
+	return p, nil
+}
+
+// Standard message header names, set in addition to the producer-wide headers.
+const (
+	HeaderContentType   = "content-type"
+	HeaderEncoder       = "encoder"
+	HeaderSchemaVersion = "schema-version"
+	HeaderSlot          = "slot"
+	HeaderBlockHash     = "block-hash"
+	HeaderTxID          = "tx-id"
+)
+
+// Header is a Kafka message header.
+type Header struct {
+	Key   string
+	Value string
 }
 
 // SendMessage sends a message to a Kafka topic.
 func (p *Producer) SendMessage(topic string, message interface{}) error {
+	return p.SendKeyedMessage(topic, "", nil, message)
+}
+
+// Message is a message to send as part of a batch.
+type Message struct {
+	Topic   string
+	Key     string
+	Headers []Header
+	Value   []byte
+}
+
+// ErrMessageTooLarge is returned for a message larger than the producer's maximum message size.
+var ErrMessageTooLarge = errors.New("message larger than the maximum message size")
+
+// IsMessageError reports whether a message failed because of the message itself, such as its
+// size, rather than because of the brokers. Sending the message again cannot succeed.
+func IsMessageError(err error) bool {
+	var kerr sarama.KError
+	if errors.As(err, &kerr) {
+		switch kerr {
+		case sarama.ErrMessageSizeTooLarge, sarama.ErrInvalidMessage, sarama.ErrInvalidRecord:
+			return true
+		}
+	}
+	return errors.Is(err, ErrMessageTooLarge)
+}
+
+// SendKeyedMessage sends a message to a Kafka topic with a key and headers. Messages with the same
+// key go to the same partition, in order. An empty key leaves the partition to the partitioner.
+func (p *Producer) SendKeyedMessage(topic, key string, headers []Header, message interface{}) error {
 	// If the message is already bytes, send it directly.
 	// Otherwise, JSON marshal it.
-	var value sarama.Encoder
-	if msgBytes, ok := message.([]byte); ok {
-		value = sarama.ByteEncoder(msgBytes)
-	} else {
-		msgBytes, err := json.Marshal(message)
+	msgBytes, ok := message.([]byte)
+	if !ok {
+		var err error
+		msgBytes, err = json.Marshal(message)
 		if err != nil {
 			return err
 		}
-		value = sarama.StringEncoder(msgBytes)
+	}
+	return p.SendBatch([]Message{{Topic: topic, Key: key, Headers: headers, Value: msgBytes}})[0]
+}
+
+// SendBatch sends messages and waits until all of them are acknowledged or have failed. It returns
+// the error of each message, nil if it was sent. In sync mode messages are sent one after the
+// other; in async mode they are all in flight at once and batched by the producer.
+func (p *Producer) SendBatch(messages []Message) []error {
+	errs := make([]error, len(messages))
+	if p.async == nil {
+		for i, m := range messages {
+			msg := p.producerMessage(m)
+			if errs[i] = p.checkSize(msg); errs[i] != nil {
+				continue
+			}
+			start := time.Now()
+			_, _, err := p.producer.SendMessage(msg)
+			observeSend(m.Topic, start, err)
+			errs[i] = err
+		}
+		return errs
+	}
+
+	// Messages that are too large are not queued.
+	queued := make([]*sarama.ProducerMessage, 0, len(messages))
+	for i, m := range messages {
+		msg := p.producerMessage(m)
+		if errs[i] = p.checkSize(msg); errs[i] != nil {
+			continue
+		}
+		msg.Metadata = i
+		queued = append(queued, msg)
+	}
+	if len(queued) == 0 {
+		return errs
+	}
+
+	p.batchMu.Lock()
+	defer p.batchMu.Unlock()
+
+	start := time.Now()
+	// Results are read while messages are queued, as the producer blocks once its buffers are full.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for pending := len(queued); pending > 0; pending-- {
+			select {
+			case msg := <-p.async.Successes():
+				i := msg.Metadata.(int)
+				observeSend(messages[i].Topic, start, nil)
+			case perr := <-p.async.Errors():
+				i := perr.Msg.Metadata.(int)
+				observeSend(messages[i].Topic, start, perr.Err)
+				errs[i] = perr.Err
+			}
+		}
+	}()
+	for _, msg := range queued {
+		p.async.Input() <- msg
+	}
+	<-done
+	metrics.KafkaBatchDuration.Observe(time.Since(start).Seconds())
+	return errs
+}
+
+func (p *Producer) producerMessage(m Message) *sarama.ProducerMessage {
+	recordHeaders := p.headers
+	if len(m.Headers) > 0 {
+		recordHeaders = make([]sarama.RecordHeader, 0, len(p.headers)+len(m.Headers))
+		recordHeaders = append(recordHeaders, p.headers...)
+		for _, h := range m.Headers {
+			recordHeaders = append(recordHeaders, sarama.RecordHeader{Key: []byte(h.Key), Value: []byte(h.Value)})
+		}
 	}
 
 	msg := &sarama.ProducerMessage{
-		Topic: topic,
-		Value: value,
+		Topic:   m.Topic,
+		Value:   sarama.ByteEncoder(m.Value),
+		Headers: recordHeaders,
 	}
+	if m.Key != "" {
+		msg.Key = sarama.StringEncoder(m.Key)
+	}
+	return msg
+}
+
+// checkSize returns ErrMessageTooLarge if the producer would reject the message for its size.
+// Sarama reports such messages with a configuration error, which does not tell them apart.
+func (p *Producer) checkSize(msg *sarama.ProducerMessage) error {
+	if size := msg.ByteSize(p.recordVersion); size > p.maxMessageBytes {
+		metrics.KafkaSendErrors.WithLabelValues(msg.Topic).Inc()
+		return fmt.Errorf("%w: %d > %d bytes", ErrMessageTooLarge, size, p.maxMessageBytes)
+	}
+	return nil
+}
+
+// observeSend records the latency of a send, from the given start until its acknowledgement.
+func observeSend(topic string, start time.Time, err error) {
+	metrics.KafkaSendDuration.WithLabelValues(topic).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.KafkaSendErrors.WithLabelValues(topic).Inc()
+	}
+}
 
-	_, _, err := p.producer.SendMessage(msg)
-	return err
+// Ping checks that the producer is open and that the brokers are reachable.
+func (p *Producer) Ping() error {
+	if p.client.Closed() {
+		return errors.New("kafka client is closed")
+	}
+	return p.client.RefreshMetadata()
 }
 
-// Close closes the producer.
+// Close closes the producer and its underlying client.
 func (p *Producer) Close() error {
-	return p.producer.Close()
+	var err error
+	if p.async != nil {
+		err = p.async.Close()
+	} else {
+		err = p.producer.Close()
+	}
+	if err != nil {
+		p.client.Close()
+		return err
+	}
+	return p.client.Close()
 }
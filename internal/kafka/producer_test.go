@@ -0,0 +1,131 @@
+// internal/kafka/producer_test.go
+package kafka
+
+import (
+	"cardano-tx-sync/config"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+const testTopic = "txs"
+
+// newMockProducer starts a mock broker leading every partition of the test topic, with a given
+// latency per request, and a producer connected to it.
+func newMockProducer(tb testing.TB, async bool, latency time.Duration) *Producer {
+	tb.Helper()
+	broker := sarama.NewMockBroker(tb, 1)
+	broker.SetLatency(latency)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(tb).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(testTopic, 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(tb),
+	})
+	tb.Cleanup(broker.Close)
+
+	p, err := NewProducer(config.KafkaConfig{
+		Brokers:      []string{broker.Addr()},
+		Async:        async,
+		RequiredAcks: "all",
+		MaxRetries:   3,
+	}, map[string]string{"network": "mainnet"})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { p.Close() })
+	return p
+}
+
+func testBatch(n int) []Message {
+	messages := make([]Message, n)
+	for i := range messages {
+		messages[i] = Message{Topic: testTopic, Key: fmt.Sprintf("key-%d", i%4), Value: []byte(fmt.Sprintf("message %d", i))}
+	}
+	return messages
+}
+
+func TestSendBatch(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		t.Run(fmt.Sprintf("async=%v", async), func(t *testing.T) {
+			p := newMockProducer(t, async, 0)
+			errs := p.SendBatch(testBatch(20))
+			if len(errs) != 20 {
+				t.Fatalf("got %d results for 20 messages", len(errs))
+			}
+			for i, err := range errs {
+				if err != nil {
+					t.Errorf("message %d: %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSendBatchTooLarge(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		t.Run(fmt.Sprintf("async=%v", async), func(t *testing.T) {
+			p := newMockProducer(t, async, 0)
+			batch := testBatch(3)
+			batch[1].Value = make([]byte, p.maxMessageBytes)
+			errs := p.SendBatch(batch)
+			if !errors.Is(errs[1], ErrMessageTooLarge) {
+				t.Errorf("large message: got %v, want ErrMessageTooLarge", errs[1])
+			}
+			if errs[0] != nil || errs[2] != nil {
+				t.Errorf("other messages: got %v and %v", errs[0], errs[2])
+			}
+		})
+	}
+}
+
+func TestIsMessageError(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{fmt.Errorf("%w: 2000000 > 1000000 bytes", ErrMessageTooLarge), true},
+		{sarama.ErrMessageSizeTooLarge, true},
+		{sarama.ErrInvalidRecord, true},
+		{sarama.ErrOutOfBrokers, false},
+		{sarama.ErrNotLeaderForPartition, false},
+		{sarama.ErrRequestTimedOut, false},
+		{errors.New("dial tcp: connection refused"), false},
+	} {
+		if got := IsMessageError(tt.err); got != tt.want {
+			t.Errorf("IsMessageError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestAsyncKeepsOneRequestInFlight(t *testing.T) {
+	c, err := newSaramaConfig(config.KafkaConfig{Brokers: []string{"localhost:9092"}, Async: true, RequiredAcks: "all"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Net.MaxOpenRequests != 1 {
+		t.Errorf("MaxOpenRequests = %d in async mode, want 1", c.Net.MaxOpenRequests)
+	}
+}
+
+// BenchmarkSendBatch compares publishing a block's messages one by one and as one async batch,
+// against a broker answering each request after 1ms.
+func BenchmarkSendBatch(b *testing.B) {
+	for _, async := range []bool{false, true} {
+		b.Run(fmt.Sprintf("async=%v", async), func(b *testing.B) {
+			p := newMockProducer(b, async, time.Millisecond)
+			batch := testBatch(100)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, err := range p.SendBatch(batch) {
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// internal/metrics/metrics.go
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "cardano_tx_sync"
+
+var (
+	// BlocksProcessed counts blocks handled on roll-forward.
+	BlocksProcessed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blocks_processed_total",
+		Help:      "Number of blocks processed on roll-forward.",
+	})
+
+	// LastBlockSlot is the slot of the last processed block.
+	LastBlockSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_block_slot",
+		Help:      "Slot of the last processed block.",
+	})
+
+	// TipSlot is the slot of the node tip as reported by Ogmios.
+	TipSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tip_slot",
+		Help:      "Slot of the node tip as reported by Ogmios.",
+	})
+
+	// TipLagSlots is the number of slots between the last processed block and the node tip.
+	TipLagSlots = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tip_lag_slots",
+		Help:      "Number of slots between the last processed block and the node tip.",
+	})
+
+	// Rollbacks counts rollbacks received from Ogmios.
+	Rollbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rollbacks_total",
+		Help:      "Number of rollbacks received from Ogmios.",
+	})
+
+	// RollbackDepth observes how many slots each rollback went back.
+	RollbackDepth = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rollback_depth_slots",
+		Help:      "Number of slots rolled back, relative to the last processed block.",
+		Buckets:   []float64{0, 20, 60, 120, 300, 600, 1800, 3600, 21600, 43200},
+	})
+
+	// TxsMatched counts transactions matched by at least one mapping of a given type.
+	TxsMatched = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "txs_matched_total",
+		Help:      "Number of transactions matched, by mapping type.",
+	}, []string{"mapping_type"})
+
+	// MessagesPublished counts messages successfully published, by topic and encoder.
+	MessagesPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_published_total",
+		Help:      "Number of messages published to Kafka, by topic and encoder.",
+	}, []string{"topic", "encoder"})
+
+	// EncodeFailures counts messages that could not be encoded.
+	EncodeFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "encode_failures_total",
+		Help:      "Number of messages that failed to encode, by encoder.",
+	}, []string{"encoder"})
+
+	// DeadLetters counts messages that could not be encoded or published, by stage and encoder.
+	DeadLetters = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dead_letters_total",
+		Help:      "Number of dead-lettered messages, by stage (encode or publish) and encoder.",
+	}, []string{"stage", "encoder"})
+
+	// KafkaSendDuration observes the latency of Kafka sends.
+	KafkaSendDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "kafka_send_duration_seconds",
+		Help:      "Latency of Kafka sends, by topic.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	// KafkaBatchDuration observes how long a batch of messages takes to be acknowledged.
+	KafkaBatchDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "kafka_batch_duration_seconds",
+		Help:      "Time for all messages of a batch to be acknowledged.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	// KafkaSendErrors counts failed Kafka sends.
+	KafkaSendErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_send_errors_total",
+		Help:      "Number of failed Kafka sends, by topic.",
+	}, []string{"topic"})
+
+	// MappingCacheLookups counts mapping cache lookups; the hit ratio is hit / (hit + miss).
+	MappingCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mapping_cache_lookups_total",
+		Help:      "Number of mapping cache lookups, by result (hit or miss).",
+	}, []string{"result"})
+)
@@ -1,6 +1,12 @@
 package model
 
-import "github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+)
 
 // MappingType defines the type of a mapping.
 type MappingType string
@@ -16,16 +22,58 @@ const (
 	MappingTypeProposal MappingType = "proposal"
 	// MappingTypeVote maps transactions with any governance vote. Key should be "*".
 	MappingTypeVote MappingType = "vote"
+	// MappingTypeStakeCredential maps transactions paying to or spending from any address with a
+	// specific stake credential. Key is the hex-encoded credential hash.
+	MappingTypeStakeCredential MappingType = "stake_credential"
 )
 
 // Mapping represents a filter-to-Kafka-topic mapping.
 type Mapping struct {
-	ID      int         `json:"id" db:"id"`
-	GroupID *int        `json:"group_id,omitempty" db:"group_id"`
-	Type    MappingType `json:"type" db:"type"`
-	Key     string      `json:"key" db:"key"`
-	Topic   string      `json:"topic" db:"topic"`
-	Encoder string      `json:"encoder,omitempty" db:"encoder"`
+	ID             int             `json:"id" db:"id"`
+	GroupID        *int            `json:"group_id,omitempty" db:"group_id"`
+	Type           MappingType     `json:"type" db:"type"`
+	Key            string          `json:"key" db:"key"`
+	Topic          string          `json:"topic" db:"topic"`
+	Encoder        string          `json:"encoder,omitempty" db:"encoder"`
+	EncoderOptions json.RawMessage `json:"encoder_options,omitempty" db:"encoder_options"`
+	BlueprintID    *int            `json:"blueprint_id,omitempty" db:"blueprint_id"`
+	// PartitionKey selects the Kafka message key, and so the partition, of the mapping's messages.
+	PartitionKey PartitionKeyStrategy `json:"partition_key,omitempty" db:"partition_key"`
+}
+
+// PartitionKeyStrategy defines how the Kafka message key of a mapping's messages is chosen.
+// Kafka only orders messages within a partition, and messages with the same key share one.
+type PartitionKeyStrategy string
+
+const (
+	// PartitionKeyNone sends messages without a key, spread over all partitions.
+	PartitionKeyNone PartitionKeyStrategy = "none"
+	// PartitionKeyMappingKey keys messages by the mapping key, such as the watched address or
+	// policy ID, so that each watched entity's transactions are in chain order.
+	PartitionKeyMappingKey PartitionKeyStrategy = "mapping_key"
+	// PartitionKeyTopic keys messages by topic name, so that the whole topic is in chain order.
+	PartitionKeyTopic PartitionKeyStrategy = "topic"
+	// PartitionKeyTxID keys messages by transaction ID, for compacted topics.
+	PartitionKeyTxID PartitionKeyStrategy = "tx_id"
+	// PartitionKeyAddress keys messages by the matched address: the mapping key of address
+	// mappings, the first output address otherwise.
+	PartitionKeyAddress PartitionKeyStrategy = "address"
+	// PartitionKeyPolicyID keys messages by the matched policy ID: the mapping key of policy ID
+	// mappings, the first minted or output policy otherwise.
+	PartitionKeyPolicyID PartitionKeyStrategy = "policy_id"
+	// PartitionKeyStakeCredential keys messages by the stake credential hash of the matched address.
+	PartitionKeyStakeCredential PartitionKeyStrategy = "stake_credential"
+	// PartitionKeyBlockHash keys messages by block hash, keeping each block's messages together.
+	PartitionKeyBlockHash PartitionKeyStrategy = "block_hash"
+)
+
+// Blueprint is an uploaded CIP-57 Plutus blueprint, referenced by mappings to decode the datums and
+// redeemers of its validators into named fields.
+type Blueprint struct {
+	ID        int             `json:"id" db:"id"`
+	Name      string          `json:"name" db:"name"`
+	Blueprint json.RawMessage `json:"blueprint,omitempty" db:"blueprint"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
 }
 
 // Checkpoint represents a point in the blockchain to sync from.
@@ -34,11 +82,58 @@ type Checkpoint struct {
 	Hash string `json:"hash" db:"hash"`
 }
 
+// Network identifies the Cardano network a message originates from
+type Network struct {
+	Name  string `json:"name"`
+	Magic uint32 `json:"magic"`
+}
+
 // TxnMessage is the message format for publishing to Kafka
 type TxnMessage struct {
 	Tx          chainsync.Tx `json:"tx"`
+	TxIndex     int          `json:"txIndex"`
 	Block       BlockDetails `json:"block"`
+	Network     Network      `json:"network"`
 	Invalidated bool         `json:"invalidated,omitempty"`
+	// ValidFrom and ValidUntil are the validity interval bounds of the transaction as UTC times.
+	ValidFrom  *time.Time `json:"validFrom,omitempty"`
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+	// TypedData holds the datums and redeemers decoded with the blueprint of the mapping, if any.
+	TypedData json.RawMessage `json:"typedData,omitempty"`
+	// ResolvedInputs are the spent outputs that were tracked because they belong to a watched
+	// address or stake credential, or hold a CIP-68 reference token of a watched policy. They are
+	// only given to encoders that resolve inputs.
+	ResolvedInputs []ResolvedInput `json:"resolvedInputs,omitempty"`
+	// UnresolvedInputs is set for the mappings of an address or stake credential whose outputs
+	// created before it was watched are unknown, when the transaction spends inputs that could not
+	// be resolved: some of them may belong to the watched entity.
+	UnresolvedInputs bool `json:"unresolvedInputs,omitempty"`
+}
+
+// OutputRef identifies a transaction output.
+type OutputRef struct {
+	TxID  string `json:"tx_id" db:"tx_id"`
+	Index int    `json:"index" db:"output_index"`
+}
+
+// TrackedOutput is an output to a watched address or stake credential, or holding a CIP-68
+// reference token of a watched policy. Tracked outputs are kept until some time after they are
+// spent, so that spending transactions can be attributed.
+type TrackedOutput struct {
+	OutputRef
+	Address string          `db:"address"`
+	Value   json.RawMessage `db:"value"`
+	// DatumHash is the hash of the output's datum, inline or by hash, empty without datum.
+	DatumHash string `db:"datum_hash"`
+	Slot      uint64 `db:"slot"`
+}
+
+// ResolvedInput is a spent output with its address, value and datum hash.
+type ResolvedInput struct {
+	Input     OutputRef    `json:"input"`
+	Address   string       `json:"address"`
+	Value     shared.Value `json:"value"`
+	DatumHash string       `json:"datumHash,omitempty"`
 }
 
 // BlockDetails contains metadata about the block
@@ -46,6 +141,55 @@ type BlockDetails struct {
 	Hash string `json:"hash"`
 	Slot uint64 `json:"slot"`
 	Era  string `json:"era"`
+	// Time is the UTC wall-clock time of the slot.
+	Time      time.Time `json:"time"`
+	Epoch     uint64    `json:"epoch"`
+	EpochSlot uint64    `json:"epochSlot"`
+	Height    uint64    `json:"height"`
+	// IssuerPoolID is the bech32 ID of the pool that forged the block. Byron blocks have none.
+	IssuerPoolID string `json:"issuerPoolId,omitempty"`
+	// Size is the size of the block in bytes.
+	Size    int64 `json:"size"`
+	TxCount int   `json:"txCount"`
+}
+
+// SyncState describes what the syncer is currently doing.
+type SyncState string
+
+const (
+	// SyncStateConnecting means the syncer is establishing a chainsync connection.
+	SyncStateConnecting SyncState = "connecting"
+	// SyncStateSyncing means the syncer is receiving blocks.
+	SyncStateSyncing SyncState = "syncing"
+	// SyncStateReconnecting means the last connection failed and the syncer is waiting to retry.
+	SyncStateReconnecting SyncState = "reconnecting"
+	// SyncStatePaused means syncing was paused through the API.
+	SyncStatePaused SyncState = "paused"
+	// SyncStateStopped means the syncer has exited.
+	SyncStateStopped SyncState = "stopped"
+)
+
+// SyncPoint is a block position reported in the sync status.
+type SyncPoint struct {
+	Slot   uint64 `json:"slot"`
+	Hash   string `json:"hash"`
+	Height uint64 `json:"height,omitempty"`
+	Era    string `json:"era,omitempty"`
+}
+
+// SyncStatus is a snapshot of the syncer's progress relative to the node tip.
+type SyncStatus struct {
+	State            SyncState  `json:"state"`
+	Endpoint         string     `json:"endpoint"`
+	Paused           bool       `json:"paused"`
+	Reconnecting     bool       `json:"reconnecting"`
+	StartedAt        time.Time  `json:"started_at"`
+	LastBlock        *SyncPoint `json:"last_block,omitempty"`
+	LastProcessedAt  *time.Time `json:"last_processed_at,omitempty"`
+	Tip              *SyncPoint `json:"tip,omitempty"`
+	SlotLag          uint64     `json:"slot_lag"`
+	EstimatedTimeLag string     `json:"estimated_time_lag"`
+	BlocksPerSecond  float64    `json:"blocks_per_second"`
 }
 
 // RollbackMessage is the message sent to Kafka to indicate a rollback
@@ -54,4 +198,129 @@ type RollbackMessage struct {
 		Slot uint64 `json:"slot"`
 		Hash string `json:"hash"`
 	} `json:"rollbackTo"`
+	Network Network `json:"network"`
+}
+
+// BlockEventType is the kind of a block feed event.
+type BlockEventType string
+
+const (
+	// BlockEventRollForward announces a block added to the chain.
+	BlockEventRollForward BlockEventType = "roll_forward"
+	// BlockEventRollBackward announces that the chain was rolled back to a point.
+	BlockEventRollBackward BlockEventType = "roll_backward"
+)
+
+// BlockEvent is published to the block feed topic. Block is set for roll forwards and
+// RollbackTo for roll backwards.
+type BlockEvent struct {
+	Type       BlockEventType `json:"type"`
+	Network    Network        `json:"network"`
+	Block      *BlockHeader   `json:"block,omitempty"`
+	RollbackTo *Checkpoint    `json:"rollbackTo,omitempty"`
+}
+
+// BlockHeader summarizes a block for the block feed.
+type BlockHeader struct {
+	BlockDetails
+	PrevHash string `json:"prevHash"`
+	// TotalFees is the sum of the fees of the block's transactions, in lovelace.
+	TotalFees uint64 `json:"totalFees"`
+	// TotalOutput is the lovelace in the outputs created by the block's transactions.
+	TotalOutput uint64 `json:"totalOutput"`
+}
+
+// AuditAction defines the kind of change recorded in the audit log.
+type AuditAction string
+
+const (
+	// AuditActionCreate records the creation of an entity.
+	AuditActionCreate AuditAction = "create"
+	// AuditActionUpdate records a change to an existing entity.
+	AuditActionUpdate AuditAction = "update"
+	// AuditActionDelete records the removal of an entity.
+	AuditActionDelete AuditAction = "delete"
+	// AuditActionSetStartPoint records a manual change of the sync start point.
+	AuditActionSetStartPoint AuditAction = "set_start_point"
+	// AuditActionPause records the syncer being paused.
+	AuditActionPause AuditAction = "pause"
+	// AuditActionResume records the syncer being resumed.
+	AuditActionResume AuditAction = "resume"
+	// AuditActionRedrive records a dead letter being published again.
+	AuditActionRedrive AuditAction = "redrive"
+)
+
+const (
+	// AuditEntityMapping is the entity type for mapping changes.
+	AuditEntityMapping = "mapping"
+	// AuditEntitySyncPoint is the entity type for sync start point changes.
+	AuditEntitySyncPoint = "sync_point"
+	// AuditEntitySyncer is the entity type for pause and resume of the syncer.
+	AuditEntitySyncer = "syncer"
+	// AuditEntityBlueprint is the entity type for blueprint changes.
+	AuditEntityBlueprint = "blueprint"
+	// AuditEntityDeadLetter is the entity type for dead letter re-drives.
+	AuditEntityDeadLetter = "dead_letter"
+)
+
+// AuditEntry is a single append-only record of a configuration change. The API is not
+// authenticated, so the change is attributed to the client IP; the actor named by the client, if
+// any, is kept as claimed and cannot be trusted.
+type AuditEntry struct {
+	ID           int64           `json:"id" db:"id"`
+	ClientIP     string          `json:"client_ip" db:"client_ip"`
+	ClaimedActor *string         `json:"claimed_actor,omitempty" db:"claimed_actor"`
+	Action       AuditAction     `json:"action" db:"action"`
+	EntityType   string          `json:"entity_type" db:"entity_type"`
+	EntityID     *string         `json:"entity_id,omitempty" db:"entity_id"`
+	Before       json.RawMessage `json:"before,omitempty" db:"before_value"`
+	After        json.RawMessage `json:"after,omitempty" db:"after_value"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditFilter narrows down the audit entries returned by a query.
+type AuditFilter struct {
+	EntityType string
+	EntityID   string
+	Limit      int
+	Offset     int
+}
+
+// DeadLetterStage is the step at which a dead-lettered message failed.
+type DeadLetterStage string
+
+const (
+	// DeadLetterStageEncode is a message that its encoder failed to produce.
+	DeadLetterStageEncode DeadLetterStage = "encode"
+	// DeadLetterStagePublish is an encoded message that Kafka did not accept.
+	DeadLetterStagePublish DeadLetterStage = "publish"
+)
+
+// DeadLetter is a message that could not be encoded or published. It keeps the transaction
+// message, including the raw Ogmios transaction under "tx", so that it can be re-driven.
+type DeadLetter struct {
+	ID        int64           `json:"id" db:"id"`
+	Stage     DeadLetterStage `json:"stage" db:"stage"`
+	TxID      string          `json:"tx_id" db:"tx_id"`
+	Slot      uint64          `json:"slot" db:"slot"`
+	BlockHash string          `json:"block_hash" db:"block_hash"`
+	// MappingID is the mapping the message was produced for, nil if it was removed since.
+	MappingID *int            `json:"mapping_id" db:"mapping_id"`
+	Encoder   string          `json:"encoder" db:"encoder"`
+	Topic     string          `json:"topic" db:"topic"`
+	Error     string          `json:"error" db:"error"`
+	Message   json.RawMessage `json:"message" db:"message"`
+	// Attempts counts the re-drives. RedrivenAt is set once one succeeds.
+	Attempts   int        `json:"attempts" db:"attempts"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	RedrivenAt *time.Time `json:"redriven_at,omitempty" db:"redriven_at"`
+}
+
+// DeadLetterFilter narrows down the dead letters returned by a query.
+type DeadLetterFilter struct {
+	TxID string
+	// Pending only returns the dead letters that were not successfully re-driven.
+	Pending bool
+	Limit   int
+	Offset  int
 }
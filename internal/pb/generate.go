@@ -0,0 +1,6 @@
+// internal/pb/generate.go
+package pb
+
+// The protobuf types in this package are generated from proto/event.proto.
+// Regenerating requires protoc and protoc-gen-gogofaster (github.com/gogo/protobuf v1.3.2) on the PATH.
+//go:generate protoc --proto_path=../../proto --gogofaster_out=Mgoogle/protobuf/struct.proto=github.com/gogo/protobuf/types,Mgoogle/protobuf/timestamp.proto=github.com/gogo/protobuf/types,paths=source_relative:. event.proto
@@ -0,0 +1,457 @@
+// internal/plutus/blueprint.go
+package plutus
+
+import (
+	"cardano-tx-sync/internal/address"
+	"cardano-tx-sync/internal/utils"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+)
+
+// definitionsRefPrefix is the prefix of the references to the blueprint definitions.
+const definitionsRefPrefix = "#/definitions/"
+
+// redeemerPurposes are the validator purposes, as used in Ogmios redeemer keys and as the
+// last segment of the validator titles generated by Aiken.
+var redeemerPurposes = map[string]bool{
+	"spend": true, "mint": true, "publish": true, "withdraw": true, "vote": true, "propose": true,
+}
+
+// Blueprint is a CIP-57 Plutus contract blueprint, used to decode the datums and redeemers of its
+// validators into named fields.
+type Blueprint struct {
+	Preamble struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"preamble"`
+	Validators  []Validator        `json:"validators"`
+	Definitions map[string]*Schema `json:"definitions"`
+}
+
+// Validator is a validator of a blueprint.
+type Validator struct {
+	Title    string    `json:"title"`
+	Hash     string    `json:"hash"`
+	Datum    *Argument `json:"datum"`
+	Redeemer *Argument `json:"redeemer"`
+}
+
+// Argument is the datum or redeemer of a validator.
+type Argument struct {
+	Title  string  `json:"title"`
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a CIP-57 Plutus data schema.
+type Schema struct {
+	Title    string    `json:"title"`
+	Ref      string    `json:"$ref"`
+	DataType string    `json:"dataType"`
+	Index    *uint64   `json:"index"`
+	Fields   []*Schema `json:"fields"`
+	Items    Items     `json:"items"`
+	Keys     *Schema   `json:"keys"`
+	Values   *Schema   `json:"values"`
+	AnyOf    []*Schema `json:"anyOf"`
+	OneOf    []*Schema `json:"oneOf"`
+}
+
+// Items is the items schema of a list: a single schema for all items, or one per item for tuples.
+type Items struct {
+	Schema *Schema
+	Tuple  []*Schema
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Items) UnmarshalJSON(raw []byte) error {
+	if trimmed := strings.TrimSpace(string(raw)); strings.HasPrefix(trimmed, "[") {
+		return json.Unmarshal(raw, &i.Tuple)
+	}
+	return json.Unmarshal(raw, &i.Schema)
+}
+
+// ParseBlueprint parses a CIP-57 blueprint and checks that all its references resolve.
+func ParseBlueprint(raw []byte) (*Blueprint, error) {
+	var b Blueprint
+	if err := json.Unmarshal(raw, &b); err != nil {
+		return nil, fmt.Errorf("invalid blueprint: %w", err)
+	}
+	if len(b.Validators) == 0 {
+		return nil, errors.New("invalid blueprint: no validators")
+	}
+
+	for _, v := range b.Validators {
+		for _, arg := range []*Argument{v.Datum, v.Redeemer} {
+			if arg == nil {
+				continue
+			}
+			if arg.Schema == nil {
+				return nil, fmt.Errorf("invalid blueprint: validator %s has an argument without schema", v.Title)
+			}
+			if err := b.checkRefs(arg.Schema); err != nil {
+				return nil, fmt.Errorf("invalid blueprint: validator %s: %w", v.Title, err)
+			}
+		}
+	}
+	for name, schema := range b.Definitions {
+		if err := b.checkRefs(schema); err != nil {
+			return nil, fmt.Errorf("invalid blueprint: definition %s: %w", name, err)
+		}
+	}
+	return &b, nil
+}
+
+func (b *Blueprint) checkRefs(s *Schema) error {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		if _, err := b.resolve(s); err != nil {
+			return err
+		}
+		return nil
+	}
+	children := append(append(append([]*Schema{}, s.Fields...), s.AnyOf...), s.OneOf...)
+	children = append(children, s.Items.Schema, s.Keys, s.Values)
+	children = append(children, s.Items.Tuple...)
+	for _, child := range children {
+		if err := b.checkRefs(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve follows the references of a schema to its definition.
+func (b *Blueprint) resolve(s *Schema) (*Schema, error) {
+	for i := 0; s.Ref != ""; i++ {
+		if i > maxDepth {
+			return nil, fmt.Errorf("reference loop at %s", s.Ref)
+		}
+		name, ok := strings.CutPrefix(s.Ref, definitionsRefPrefix)
+		if !ok {
+			return nil, fmt.Errorf("unsupported reference %s", s.Ref)
+		}
+		name = strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+		def, ok := b.Definitions[name]
+		if !ok || def == nil {
+			return nil, fmt.Errorf("undefined reference %s", s.Ref)
+		}
+		s = def
+	}
+	return s, nil
+}
+
+// DecodeData decodes Plutus data according to a schema. Records are decoded into objects keyed by
+// field title, constructors of sum types into {"<title>": fields}, or their title when they have no
+// fields. Integers are exact JSON numbers and byte strings are hex-encoded.
+func (b *Blueprint) DecodeData(schema *Schema, data Data) (interface{}, error) {
+	return b.decode(schema, data, 0)
+}
+
+func (b *Blueprint) decode(schema *Schema, data Data, depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("nesting deeper than %d", maxDepth)
+	}
+	schema, err := b.resolve(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schema.AnyOf) > 0 {
+		return b.decodeSum(schema.AnyOf, data, depth)
+	}
+	if len(schema.OneOf) > 0 {
+		return b.decodeSum(schema.OneOf, data, depth)
+	}
+
+	switch schema.DataType {
+	case "":
+		// An empty schema accepts any data, which is kept in the detailed schema.
+		return data, nil
+
+	case "integer":
+		i, ok := data.(Int)
+		if !ok {
+			return nil, fmt.Errorf("expected integer, got %s", kind(data))
+		}
+		return json.Number(i.String()), nil
+
+	case "bytes":
+		bs, ok := data.(Bytes)
+		if !ok {
+			return nil, fmt.Errorf("expected bytes, got %s", kind(data))
+		}
+		return hex.EncodeToString(bs), nil
+
+	case "list":
+		list, ok := data.(List)
+		if !ok {
+			return nil, fmt.Errorf("expected list, got %s", kind(data))
+		}
+		if schema.Items.Tuple != nil && len(schema.Items.Tuple) != len(list) {
+			return nil, fmt.Errorf("expected tuple of %d items, got %d", len(schema.Items.Tuple), len(list))
+		}
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			itemSchema := schema.Items.Schema
+			if schema.Items.Tuple != nil {
+				itemSchema = schema.Items.Tuple[i]
+			}
+			if itemSchema == nil {
+				items[i] = item
+				continue
+			}
+			if items[i], err = b.decode(itemSchema, item, depth+1); err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		return items, nil
+
+	case "map":
+		m, ok := data.(Map)
+		if !ok {
+			return nil, fmt.Errorf("expected map, got %s", kind(data))
+		}
+		type entry struct {
+			Key   interface{} `json:"key"`
+			Value interface{} `json:"value"`
+		}
+		entries := make([]entry, len(m))
+		for i, pair := range m {
+			entries[i] = entry{Key: pair.Key, Value: pair.Value}
+			if schema.Keys != nil {
+				if entries[i].Key, err = b.decode(schema.Keys, pair.Key, depth+1); err != nil {
+					return nil, fmt.Errorf("key %d: %w", i, err)
+				}
+			}
+			if schema.Values != nil {
+				if entries[i].Value, err = b.decode(schema.Values, pair.Value, depth+1); err != nil {
+					return nil, fmt.Errorf("value %d: %w", i, err)
+				}
+			}
+		}
+		return entries, nil
+
+	case "constructor":
+		return b.decodeConstr(schema, data, depth)
+
+	default:
+		return nil, fmt.Errorf("unsupported data type %s", schema.DataType)
+	}
+}
+
+// decodeSum decodes a constructor of a sum type. A single alternative is decoded as a record.
+func (b *Blueprint) decodeSum(alternatives []*Schema, data Data, depth int) (interface{}, error) {
+	if len(alternatives) == 1 {
+		return b.decode(alternatives[0], data, depth+1)
+	}
+
+	c, ok := data.(Constr)
+	if !ok {
+		return nil, fmt.Errorf("expected constructor, got %s", kind(data))
+	}
+	for _, alt := range alternatives {
+		resolved, err := b.resolve(alt)
+		if err != nil {
+			return nil, err
+		}
+		if resolved.DataType != "constructor" || resolved.Index == nil || *resolved.Index != c.Index {
+			continue
+		}
+
+		title := resolved.Title
+		if title == "" {
+			title = strconv.FormatUint(c.Index, 10)
+		}
+		if len(c.Fields) == 0 && len(resolved.Fields) == 0 {
+			return title, nil
+		}
+		value, err := b.decodeConstr(resolved, data, depth)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", title, err)
+		}
+		return map[string]interface{}{title: value}, nil
+	}
+	return nil, fmt.Errorf("no alternative for constructor %d", c.Index)
+}
+
+// decodeConstr decodes the fields of a constructor: into an object when all fields have a title,
+// into an array otherwise.
+func (b *Blueprint) decodeConstr(schema *Schema, data Data, depth int) (interface{}, error) {
+	c, ok := data.(Constr)
+	if !ok {
+		return nil, fmt.Errorf("expected constructor, got %s", kind(data))
+	}
+	if schema.Index != nil && *schema.Index != c.Index {
+		return nil, fmt.Errorf("expected constructor %d, got %d", *schema.Index, c.Index)
+	}
+	if len(schema.Fields) != len(c.Fields) {
+		return nil, fmt.Errorf("expected %d fields, got %d", len(schema.Fields), len(c.Fields))
+	}
+
+	named := true
+	for _, field := range schema.Fields {
+		named = named && field.Title != ""
+	}
+
+	values := make([]interface{}, len(c.Fields))
+	for i, field := range schema.Fields {
+		value, err := b.decode(field, c.Fields[i], depth+1)
+		if err != nil {
+			name := field.Title
+			if name == "" {
+				name = strconv.Itoa(i)
+			}
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		values[i] = value
+	}
+
+	if !named {
+		return values, nil
+	}
+	record := make(map[string]interface{}, len(values))
+	for i, field := range schema.Fields {
+		record[field.Title] = values[i]
+	}
+	return record, nil
+}
+
+func kind(data Data) string {
+	switch data.(type) {
+	case Constr:
+		return "constructor"
+	case Map:
+		return "map"
+	case List:
+		return "list"
+	case Int:
+		return "integer"
+	case Bytes:
+		return "bytes"
+	default:
+		return "unknown"
+	}
+}
+
+// TypedTxData holds the datums and redeemers of a transaction that belong to the validators of a
+// blueprint, decoded into named fields.
+type TypedTxData struct {
+	Blueprint string `json:"blueprint"`
+	// Datums are the witness-set datums, keyed by datum hash.
+	Datums map[string]TypedValue `json:"datums"`
+	// OutputDatums are the inline datums of the outputs, keyed by output index.
+	OutputDatums map[int]TypedValue `json:"outputDatums"`
+	// Redeemers are keyed by "<purpose>:<index>", e.g. "spend:0".
+	Redeemers map[string]TypedValue `json:"redeemers"`
+}
+
+// TypedValue is a datum or redeemer decoded with the schema of a validator.
+type TypedValue struct {
+	Validator string      `json:"validator"`
+	Value     interface{} `json:"value"`
+}
+
+// DecodeTx decodes the datums and redeemers of a transaction with the schemas of the blueprint.
+// Datums of outputs locked by a validator of the blueprint, and redeemers of minting policies of
+// the blueprint, are decoded with that validator. Other datums and redeemers are decoded with the
+// first validator whose schema they match, and are left out if none does.
+func (b *Blueprint) DecodeTx(tx chainsync.Tx) *TypedTxData {
+	typed := &TypedTxData{
+		Blueprint:    b.Preamble.Title,
+		Datums:       make(map[string]TypedValue),
+		OutputDatums: make(map[int]TypedValue),
+		Redeemers:    make(map[string]TypedValue),
+	}
+	data := DecodeTx(tx)
+
+	for i, out := range tx.Outputs {
+		scriptHash, ok := address.PaymentScriptHash(out.Address)
+		if !ok {
+			continue
+		}
+		for _, v := range b.validatorsWithHash(scriptHash) {
+			if v.Datum == nil {
+				continue
+			}
+			if datum, ok := data.OutputDatums[i]; ok {
+				if value, err := b.DecodeData(v.Datum.Schema, datum); err == nil {
+					typed.OutputDatums[i] = TypedValue{Validator: v.Title, Value: value}
+				}
+			}
+			if datum, ok := data.Datums[out.DatumHash]; ok && out.DatumHash != "" {
+				if value, err := b.DecodeData(v.Datum.Schema, datum); err == nil {
+					typed.Datums[out.DatumHash] = TypedValue{Validator: v.Title, Value: value}
+				}
+			}
+		}
+	}
+
+	// Datums of spent outputs are not attached to any output of the transaction.
+	for hash, datum := range data.Datums {
+		if _, ok := typed.Datums[hash]; ok {
+			continue
+		}
+		for _, v := range b.Validators {
+			if v.Datum == nil {
+				continue
+			}
+			if value, err := b.DecodeData(v.Datum.Schema, datum); err == nil {
+				typed.Datums[hash] = TypedValue{Validator: v.Title, Value: value}
+				break
+			}
+		}
+	}
+
+	policies := utils.SortedPolicies(tx.Mint)
+	for key, redeemer := range data.Redeemers {
+		purpose, indexStr, _ := strings.Cut(key, ":")
+		candidates := b.validatorsForPurpose(purpose)
+		if index, err := strconv.Atoi(indexStr); err == nil && purpose == "mint" && index < len(policies) {
+			if byHash := b.validatorsWithHash(policies[index]); len(byHash) > 0 {
+				candidates = byHash
+			}
+		}
+		for _, v := range candidates {
+			if v.Redeemer == nil {
+				continue
+			}
+			if value, err := b.DecodeData(v.Redeemer.Schema, redeemer); err == nil {
+				typed.Redeemers[key] = TypedValue{Validator: v.Title, Value: value}
+				break
+			}
+		}
+	}
+
+	return typed
+}
+
+func (b *Blueprint) validatorsWithHash(hash string) []Validator {
+	var validators []Validator
+	for _, v := range b.Validators {
+		if v.Hash != "" && strings.EqualFold(v.Hash, hash) {
+			validators = append(validators, v)
+		}
+	}
+	return validators
+}
+
+// validatorsForPurpose returns the validators that may have the given purpose, based on the
+// last segment of their title. Validators without a purpose in their title may have any purpose.
+func (b *Blueprint) validatorsForPurpose(purpose string) []Validator {
+	var validators []Validator
+	for _, v := range b.Validators {
+		suffix := v.Title[strings.LastIndexByte(v.Title, '.')+1:]
+		if !redeemerPurposes[suffix] || suffix == purpose {
+			validators = append(validators, v)
+		}
+	}
+	return validators
+}
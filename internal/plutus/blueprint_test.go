@@ -0,0 +1,275 @@
+// internal/plutus/blueprint_test.go
+package plutus
+
+import (
+	"cardano-tx-sync/internal/address"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync/num"
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// testdata/plutus.json is an Aiken blueprint: a marketplace spending validator whose datum uses a
+// map, a list and an Option, and a minting policy. Both have the "else" handler Aiken generates.
+const (
+	marketplaceHash = "5e1a6e4bba3a1fee06c0ef4de0a6f4a1ab02b0a8c5da4e36b4f3e1d2"
+	tokensHash      = "8f5d3b2a1c0e9f7d6b5a4c3e2d1f0a9b8c7d6e5f4a3b2c1d0e9f8a7b"
+	// marketplaceAddress is the enterprise address of the marketplace script.
+	marketplaceAddress = "addr1w90p5mjthgaplmsxcrh5mc9x7js6kq4s4rza5n3kkne7r5sul9v97"
+)
+
+var (
+	seller   = strings.Repeat("11", 28)
+	delegate = strings.Repeat("22", 28)
+)
+
+func loadBlueprint(t *testing.T) *Blueprint {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", "plutus.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ParseBlueprint(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// cborHex encodes Plutus data built from constr, bytesOf, mapOf and Go integers and slices.
+func cborHex(t *testing.T, v interface{}) string {
+	t.Helper()
+	raw, err := cbor.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(raw)
+}
+
+func constr(index uint64, fields ...interface{}) cbor.Tag {
+	if fields == nil {
+		fields = []interface{}{}
+	}
+	return cbor.Tag{Number: 121 + index, Content: fields}
+}
+
+func bytesOf(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// mapOf encodes a Plutus map with a single entry.
+func mapOf(key, value interface{}) cbor.RawMessage {
+	k, _ := cbor.Marshal(key)
+	v, _ := cbor.Marshal(value)
+	return append(append([]byte{0xa1}, k...), v...)
+}
+
+func listing(delegateOption cbor.Tag) cbor.Tag {
+	return constr(0,
+		bytesOf(seller),
+		25000000,
+		mapOf(bytesOf("aa"), 250),
+		[]interface{}{bytesOf("bb"), bytesOf("cc")},
+		delegateOption,
+	)
+}
+
+func decodeJSON(t *testing.T, b *Blueprint, schema *Schema, cborHex string) (string, error) {
+	t.Helper()
+	data, err := DecodeHex(cborHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := b.DecodeData(schema, data)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out), nil
+}
+
+func TestParseAikenBlueprint(t *testing.T) {
+	b := loadBlueprint(t)
+	if b.Preamble.Title != "acme/marketplace" || len(b.Validators) != 4 {
+		t.Fatalf("parsed %q with %d validators", b.Preamble.Title, len(b.Validators))
+	}
+
+	// Escaped references, e.g. "#/definitions/aiken~1crypto~1VerificationKeyHash", resolve to
+	// the definitions with a "/" in their name.
+	resolved, err := b.resolve(&Schema{Ref: "#/definitions/Option$aiken~1crypto~1VerificationKeyHash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Title != "Option" || len(resolved.AnyOf) != 2 {
+		t.Errorf("resolved %+v, want the Option definition", resolved)
+	}
+}
+
+func TestParseBlueprintInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		blueprint string
+		wantErr   string
+	}{
+		{"no validators", `{"validators": []}`, "no validators"},
+		{"undefined reference", `{"validators": [{"title": "v.v.spend", "datum": {"schema": {"$ref": "#/definitions/Missing"}}}]}`, "undefined reference"},
+		{"external reference", `{"validators": [{"title": "v.v.spend", "datum": {"schema": {"$ref": "other.json#/Datum"}}}]}`, "unsupported reference"},
+		{"reference loop", `{"validators": [{"title": "v.v.spend", "datum": {"schema": {"$ref": "#/definitions/A"}}}],
+			"definitions": {"A": {"$ref": "#/definitions/B"}, "B": {"$ref": "#/definitions/A"}}}`, "reference loop"},
+		{"missing schema", `{"validators": [{"title": "v.v.spend", "redeemer": {"title": "r"}}]}`, "without schema"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBlueprint([]byte(tt.blueprint))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeData(t *testing.T) {
+	b := loadBlueprint(t)
+	datum := b.Validators[0].Datum.Schema
+	redeemer := b.Validators[0].Redeemer.Schema
+	mint := b.Validators[2].Redeemer.Schema
+
+	tests := []struct {
+		name   string
+		schema *Schema
+		data   interface{}
+		want   string
+	}{
+		{
+			name:   "record with map, list and Some",
+			schema: datum,
+			data:   listing(constr(0, bytesOf(delegate))),
+			want: fmt.Sprintf(`{"allowed_buyers":["bb","cc"],"delegate":{"Some":["%s"]},"price":25000000,`+
+				`"royalties":[{"key":"aa","value":250}],"seller":"%s"}`, delegate, seller),
+		},
+		{
+			name:   "record with None",
+			schema: datum,
+			data:   listing(constr(1)),
+			want: fmt.Sprintf(`{"allowed_buyers":["bb","cc"],"delegate":"None","price":25000000,`+
+				`"royalties":[{"key":"aa","value":250}],"seller":"%s"}`, seller),
+		},
+		{name: "constructor with named field", schema: redeemer, data: constr(0, bytesOf(delegate)), want: fmt.Sprintf(`{"Buy":{"buyer":"%s"}}`, delegate)},
+		{name: "constructor without fields", schema: redeemer, data: constr(1), want: `"Cancel"`},
+		{name: "third constructor", schema: redeemer, data: constr(2, 30000000), want: `{"Reprice":{"new_price":30000000}}`},
+		{name: "constructor with unnamed field", schema: mint, data: constr(0, 5), want: `{"Mint":[5]}`},
+		{name: "empty schema keeps the data", schema: b.Validators[1].Redeemer.Schema, data: 42, want: `{"int":42}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeJSON(t, b, tt.schema, cborHex(t, tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeDataMismatch(t *testing.T) {
+	b := loadBlueprint(t)
+	datum := b.Validators[0].Datum.Schema
+	redeemer := b.Validators[0].Redeemer.Schema
+
+	tests := []struct {
+		name    string
+		schema  *Schema
+		data    interface{}
+		wantErr string
+	}{
+		{"not a constructor", datum, 42, "expected constructor, got integer"},
+		{"wrong constructor", datum, constr(1), "expected constructor 0, got 1"},
+		{"missing field", datum, constr(0, bytesOf(seller), 25000000), "expected 5 fields, got 2"},
+		{"wrong field type", datum, constr(0, bytesOf(seller), bytesOf("00"), mapOf(bytesOf("aa"), 250), []interface{}{}, constr(1)),
+			"field price: expected integer, got bytes"},
+		{"wrong map key", datum, constr(0, bytesOf(seller), 1, mapOf(1, 250), []interface{}{}, constr(1)),
+			"field royalties: key 0: expected bytes, got integer"},
+		{"wrong list item", datum, constr(0, bytesOf(seller), 1, mapOf(bytesOf("aa"), 250), []interface{}{bytesOf("bb"), 7}, constr(1)),
+			"field allowed_buyers: item 1: expected bytes, got integer"},
+		{"wrong option", datum, listing(constr(0, 7)), "field delegate: Some: field 0: expected bytes, got integer"},
+		{"unknown redeemer", redeemer, constr(5), "no alternative for constructor 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeJSON(t, b, tt.schema, cborHex(t, tt.data))
+			if err == nil {
+				t.Fatalf("decoded %s, want an error", got)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBlueprintDecodeTx(t *testing.T) {
+	b := loadBlueprint(t)
+	if hash, ok := address.PaymentScriptHash(marketplaceAddress); !ok || hash != marketplaceHash {
+		t.Fatalf("script hash of the marketplace address is %s", hash)
+	}
+
+	listingDatum := cborHex(t, listing(constr(1)))
+	unknownDatum := cborHex(t, constr(3, 1, 2))
+	redeemers, _ := json.Marshal([]map[string]interface{}{
+		{"validator": map[string]interface{}{"index": 0, "purpose": "spend"}, "redeemer": cborHex(t, constr(1))},
+		{"validator": map[string]interface{}{"index": 0, "purpose": "mint"}, "redeemer": cborHex(t, constr(0, 5))},
+	})
+	tx := chainsync.Tx{
+		Outputs: chainsync.TxOuts{
+			{Address: marketplaceAddress, Datum: listingDatum},
+			// The same datum at an address without a validator of the blueprint is not decoded.
+			{Address: "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8", Datum: listingDatum},
+		},
+		Datums: chainsync.Datums{
+			"aa00": listingDatum,
+			"bb00": unknownDatum,
+		},
+		Redeemers: redeemers,
+		Mint:      shared.Value{tokensHash: {"": num.Int64(1)}},
+	}
+
+	typed := b.DecodeTx(tx)
+	if typed.Blueprint != "acme/marketplace" {
+		t.Errorf("blueprint is %q", typed.Blueprint)
+	}
+	if got := typed.OutputDatums[0].Validator; got != "marketplace.marketplace.spend" {
+		t.Errorf("output datum decoded by %q", got)
+	}
+	if _, ok := typed.OutputDatums[1]; ok {
+		t.Error("datum of an output outside the blueprint was decoded")
+	}
+	if got := typed.Datums["aa00"].Validator; got != "marketplace.marketplace.spend" {
+		t.Errorf("witness datum decoded by %q", got)
+	}
+	if _, ok := typed.Datums["bb00"]; ok {
+		t.Error("a datum matching no schema was decoded")
+	}
+	if got := typed.Redeemers["spend:0"]; got.Validator != "marketplace.marketplace.spend" || got.Value != "Cancel" {
+		t.Errorf("spend redeemer is %+v", got)
+	}
+	if got := typed.Redeemers["mint:0"]; got.Validator != "tokens.tokens.mint" {
+		t.Errorf("mint redeemer decoded by %q, want the policy of the minted asset", got.Validator)
+	}
+}
@@ -0,0 +1,99 @@
+// internal/plutus/data.go
+package plutus
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+)
+
+// Data is a decoded Plutus data value: a Constr, Map, List, Int or Bytes.
+// It marshals to the detailed JSON schema used by cardano-cli and Ogmios, e.g.
+// {"constructor": 0, "fields": [{"int": 42}, {"bytes": "cafe"}]}.
+type Data interface {
+	isData()
+}
+
+// Constr is a constructor application: an alternative of a sum type with its fields.
+type Constr struct {
+	Index  uint64
+	Fields []Data
+}
+
+// Map is an association list. Key order is preserved and keys may repeat.
+type Map []Pair
+
+// Pair is a key-value entry of a Map.
+type Pair struct {
+	Key   Data
+	Value Data
+}
+
+// List is a list of values.
+type List []Data
+
+// Int is an unbounded integer.
+type Int struct {
+	*big.Int
+}
+
+// Bytes is a byte string.
+type Bytes []byte
+
+func (Constr) isData() {}
+func (Map) isData()    {}
+func (List) isData()   {}
+func (Int) isData()    {}
+func (Bytes) isData()  {}
+
+// MarshalJSON implements json.Marshaler.
+func (c Constr) MarshalJSON() ([]byte, error) {
+	fields := c.Fields
+	if fields == nil {
+		fields = []Data{}
+	}
+	return json.Marshal(struct {
+		Constructor uint64 `json:"constructor"`
+		Fields      []Data `json:"fields"`
+	}{c.Index, fields})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Map) MarshalJSON() ([]byte, error) {
+	type entry struct {
+		K Data `json:"k"`
+		V Data `json:"v"`
+	}
+	entries := make([]entry, len(m))
+	for i, pair := range m {
+		entries[i] = entry{K: pair.Key, V: pair.Value}
+	}
+	return json.Marshal(struct {
+		Map []entry `json:"map"`
+	}{entries})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (l List) MarshalJSON() ([]byte, error) {
+	items := []Data(l)
+	if items == nil {
+		items = []Data{}
+	}
+	return json.Marshal(struct {
+		List []Data `json:"list"`
+	}{items})
+}
+
+// MarshalJSON implements json.Marshaler. The integer is written as an exact JSON number literal.
+func (i Int) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Int json.Number `json:"int"`
+	}{json.Number(i.String())})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Bytes string `json:"bytes"`
+	}{hex.EncodeToString(b)})
+}
@@ -0,0 +1,375 @@
+// internal/plutus/decode.go
+package plutus
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// maxDepth bounds the nesting of decoded values, so that malicious datums cannot exhaust the stack.
+const maxDepth = 256
+
+// CBOR major types.
+const (
+	majorUnsigned = 0
+	majorNegative = 1
+	majorBytes    = 2
+	majorArray    = 4
+	majorMap      = 5
+	majorTag      = 6
+	majorSimple   = 7
+)
+
+// indefinite is the additional information of indefinite-length items, and break the stop code
+// that ends them.
+const (
+	indefinite = 31
+	breakCode  = 0xff
+)
+
+// Tags used by the Plutus data encoding.
+const (
+	tagPositiveBignum = 2
+	tagNegativeBignum = 3
+	tagConstrGeneral  = 102
+	tagConstrSmall    = 121 // constructors 0 to 6
+	tagConstrSmallMax = 127
+	tagConstrLarge    = 1280 // constructors 7 to 127
+	tagConstrLargeMax = 1400
+)
+
+var errUnexpectedEnd = errors.New("unexpected end of data")
+
+// DecodeHex decodes hex-encoded Plutus data CBOR, as found in datums and redeemers.
+func DecodeHex(s string) (Data, error) {
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+	return Decode(raw)
+}
+
+// Decode decodes Plutus data CBOR. The whole input must be a single data value.
+func Decode(raw []byte) (Data, error) {
+	d := &decoder{data: raw}
+	value, err := d.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plutus data at offset %d: %w", d.pos, err)
+	}
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("invalid plutus data: %d trailing bytes", len(d.data)-d.pos)
+	}
+	return value, nil
+}
+
+// DecodeHexJSON decodes hex-encoded Plutus data CBOR into its detailed-schema JSON.
+func DecodeHexJSON(s string) (json.RawMessage, error) {
+	value, err := DecodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// decoder reads the CBOR subset used by Plutus data. A generic CBOR decoder is not used because
+// Plutus maps are association lists: decoding them into Go maps would lose their key order and
+// repeated keys, and reject byte string keys.
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *decoder) decode(depth int) (Data, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("nesting deeper than %d", maxDepth)
+	}
+
+	major, info, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUnsigned:
+		n, err := d.readArgument(info)
+		if err != nil {
+			return nil, err
+		}
+		return Int{new(big.Int).SetUint64(n)}, nil
+
+	case majorNegative:
+		n, err := d.readArgument(info)
+		if err != nil {
+			return nil, err
+		}
+		v := new(big.Int).SetUint64(n)
+		return Int{v.Neg(v).Sub(v, big.NewInt(1))}, nil
+
+	case majorBytes:
+		b, err := d.readBytes(info)
+		if err != nil {
+			return nil, err
+		}
+		return Bytes(b), nil
+
+	case majorArray:
+		items, err := d.readArray(info, depth)
+		if err != nil {
+			return nil, err
+		}
+		return List(items), nil
+
+	case majorMap:
+		return d.readMap(info, depth)
+
+	case majorTag:
+		tag, err := d.readArgument(info)
+		if err != nil {
+			return nil, err
+		}
+		return d.readTagged(tag, depth)
+
+	default:
+		return nil, fmt.Errorf("unexpected CBOR major type %d", major)
+	}
+}
+
+func (d *decoder) readTagged(tag uint64, depth int) (Data, error) {
+	switch {
+	case tag == tagPositiveBignum || tag == tagNegativeBignum:
+		major, info, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if major != majorBytes {
+			return nil, fmt.Errorf("bignum content must be a byte string")
+		}
+		b, err := d.readBytes(info)
+		if err != nil {
+			return nil, err
+		}
+		v := new(big.Int).SetBytes(b)
+		if tag == tagNegativeBignum {
+			v.Neg(v).Sub(v, big.NewInt(1))
+		}
+		return Int{v}, nil
+
+	case tag >= tagConstrSmall && tag <= tagConstrSmallMax:
+		fields, err := d.readFields(depth)
+		if err != nil {
+			return nil, err
+		}
+		return Constr{Index: tag - tagConstrSmall, Fields: fields}, nil
+
+	case tag >= tagConstrLarge && tag <= tagConstrLargeMax:
+		fields, err := d.readFields(depth)
+		if err != nil {
+			return nil, err
+		}
+		return Constr{Index: tag - tagConstrLarge + 7, Fields: fields}, nil
+
+	case tag == tagConstrGeneral:
+		major, info, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if major != majorArray || info != 2 {
+			return nil, fmt.Errorf("general constructor must be a 2-element array")
+		}
+		indexMajor, indexInfo, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if indexMajor != majorUnsigned {
+			return nil, fmt.Errorf("constructor index must be an unsigned integer")
+		}
+		index, err := d.readArgument(indexInfo)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := d.readFields(depth)
+		if err != nil {
+			return nil, err
+		}
+		return Constr{Index: index, Fields: fields}, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected CBOR tag %d", tag)
+	}
+}
+
+// readFields reads the array of constructor fields.
+func (d *decoder) readFields(depth int) ([]Data, error) {
+	major, info, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+	if major != majorArray {
+		return nil, fmt.Errorf("constructor fields must be an array")
+	}
+	return d.readArray(info, depth)
+}
+
+func (d *decoder) readArray(info byte, depth int) ([]Data, error) {
+	if info == indefinite {
+		items := []Data{}
+		for !d.atBreak() {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, d.readBreak()
+	}
+
+	n, err := d.readLength(info)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Data, n)
+	for i := range items {
+		if items[i], err = d.decode(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+func (d *decoder) readMap(info byte, depth int) (Map, error) {
+	readPair := func() (Pair, error) {
+		key, err := d.decode(depth + 1)
+		if err != nil {
+			return Pair{}, err
+		}
+		value, err := d.decode(depth + 1)
+		if err != nil {
+			return Pair{}, err
+		}
+		return Pair{Key: key, Value: value}, nil
+	}
+
+	if info == indefinite {
+		pairs := Map{}
+		for !d.atBreak() {
+			pair, err := readPair()
+			if err != nil {
+				return nil, err
+			}
+			pairs = append(pairs, pair)
+		}
+		return pairs, d.readBreak()
+	}
+
+	n, err := d.readLength(info)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make(Map, n)
+	for i := range pairs {
+		if pairs[i], err = readPair(); err != nil {
+			return nil, err
+		}
+	}
+	return pairs, nil
+}
+
+// readBytes reads a byte string, concatenating the chunks of an indefinite-length one.
+func (d *decoder) readBytes(info byte) ([]byte, error) {
+	if info != indefinite {
+		n, err := d.readLength(info)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, n)
+		copy(b, d.data[d.pos:d.pos+n])
+		d.pos += n
+		return b, nil
+	}
+
+	b := []byte{}
+	for !d.atBreak() {
+		major, chunkInfo, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if major != majorBytes || chunkInfo == indefinite {
+			return nil, fmt.Errorf("invalid chunk in indefinite-length byte string")
+		}
+		chunk, err := d.readBytes(chunkInfo)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, chunk...)
+	}
+	return b, d.readBreak()
+}
+
+func (d *decoder) readHead() (byte, byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, errUnexpectedEnd
+	}
+	head := d.data[d.pos]
+	d.pos++
+	major, info := head>>5, head&0x1f
+	if major == majorSimple {
+		return 0, 0, fmt.Errorf("unexpected CBOR simple value or float")
+	}
+	return major, info, nil
+}
+
+// readArgument reads the unsigned argument following an item head.
+func (d *decoder) readArgument(info byte) (uint64, error) {
+	var size int
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, fmt.Errorf("invalid CBOR additional information %d", info)
+	}
+	if len(d.data)-d.pos < size {
+		return 0, errUnexpectedEnd
+	}
+
+	buf := make([]byte, 8)
+	copy(buf[8-size:], d.data[d.pos:d.pos+size])
+	d.pos += size
+	return binary.BigEndian.Uint64(buf), nil
+}
+
+// readLength reads the length of a definite-length item and checks it against the remaining
+// input, as every element takes at least one byte.
+func (d *decoder) readLength(info byte) (int, error) {
+	n, err := d.readArgument(info)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)-d.pos) {
+		return 0, errUnexpectedEnd
+	}
+	return int(n), nil
+}
+
+func (d *decoder) atBreak() bool {
+	return d.pos < len(d.data) && d.data[d.pos] == breakCode
+}
+
+func (d *decoder) readBreak() error {
+	if !d.atBreak() {
+		return errUnexpectedEnd
+	}
+	d.pos++
+	return nil
+}
@@ -0,0 +1,98 @@
+// internal/plutus/decode_test.go
+package plutus
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		cbor string
+		want string
+	}{
+		{"zero", "00", `{"int":0}`},
+		{"one-byte argument", "1864", `{"int":100}`},
+		{"max uint64", "1bffffffffffffffff", `{"int":18446744073709551615}`},
+		{"minus one", "20", `{"int":-1}`},
+		{"min negative", "3bffffffffffffffff", `{"int":-18446744073709551616}`},
+		{"positive bignum", "c249010000000000000000", `{"int":18446744073709551616}`},
+		{"negative bignum", "c349010000000000000000", `{"int":-18446744073709551617}`},
+		{"empty bignum", "c240", `{"int":0}`},
+		{"bytes", "43cafe01", `{"bytes":"cafe01"}`},
+		{"empty bytes", "40", `{"bytes":""}`},
+		{"indefinite bytes", "5f42cafe4101ff", `{"bytes":"cafe01"}`},
+		{"list", "83010203", `{"list":[{"int":1},{"int":2},{"int":3}]}`},
+		{"indefinite list", "9f0102ff", `{"list":[{"int":1},{"int":2}]}`},
+		{"empty indefinite list", "9fff", `{"list":[]}`},
+		{"map keeps key order", "a203040102", `{"map":[{"k":{"int":3},"v":{"int":4}},{"k":{"int":1},"v":{"int":2}}]}`},
+		{"map keeps repeated keys", "a201020103", `{"map":[{"k":{"int":1},"v":{"int":2}},{"k":{"int":1},"v":{"int":3}}]}`},
+		{"indefinite map", "bf41aa01ff", `{"map":[{"k":{"bytes":"aa"},"v":{"int":1}}]}`},
+		{"constructor 0", "d87980", `{"constructor":0,"fields":[]}`},
+		{"constructor 6 with indefinite fields", "d87f9f01ff", `{"constructor":6,"fields":[{"int":1}]}`},
+		{"constructor 7", "d9050080", `{"constructor":7,"fields":[]}`},
+		{"constructor 127", "d905788100", `{"constructor":127,"fields":[{"int":0}]}`},
+		{"general constructor", "d866821903e880", `{"constructor":1000,"fields":[]}`},
+		{"general constructor with small index", "d866820181a0", `{"constructor":1,"fields":[{"map":[]}]}`},
+		{"nested", "d8799f4100d87a80a0ff", `{"constructor":0,"fields":[{"bytes":"00"},{"constructor":1,"fields":[]},{"map":[]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeHexJSON(tt.cbor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		cbor    string
+		wantErr string
+	}{
+		{"invalid hex", "zz", "invalid hex"},
+		{"empty", "", "unexpected end"},
+		{"truncated argument", "1901", "unexpected end"},
+		{"truncated bytes", "43cafe", "unexpected end"},
+		{"truncated list", "830102", "unexpected end"},
+		{"truncated map", "a201", "unexpected end"},
+		{"missing break", "9f01", "unexpected end"},
+		{"missing bytes break", "5f41aa", "unexpected end"},
+		{"length beyond input", "5bffffffffffffffff", "unexpected end"},
+		{"trailing bytes", "0000", "1 trailing bytes"},
+		{"text string", "6161", "major type 3"},
+		{"float", "f93c00", "simple value or float"},
+		{"reserved additional information", "1c", "additional information 28"},
+		{"unknown tag", "c000", "tag 0"},
+		{"tag below constructors", "d87880", "tag 120"},
+		{"tag between constructor ranges", "d8809f", "tag 128"},
+		{"tag below large constructors", "d904ff80", "tag 1279"},
+		{"tag above large constructors", "d9057980", "tag 1401"},
+		{"constructor fields not a list", "d87901", "fields must be an array"},
+		{"general constructor not a pair", "d8668100", "2-element array"},
+		{"general constructor negative index", "d866822080", "unsigned integer"},
+		{"bignum of an integer", "c201", "byte string"},
+		{"text chunk in bytes", "5f6161ff", "invalid chunk"},
+		{"nested indefinite chunk", "5f5fffff", "invalid chunk"},
+		{"too deep", strings.Repeat("81", maxDepth+1) + "00", "nesting deeper"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeHex(tt.cbor)
+			if err == nil {
+				out, _ := json.Marshal(got)
+				t.Fatalf("decoded %s, want an error", out)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
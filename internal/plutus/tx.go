@@ -0,0 +1,100 @@
+// internal/plutus/tx.go
+package plutus
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+	"golang.org/x/crypto/blake2b"
+)
+
+// TxData holds the decoded Plutus data of a transaction. Values that are not valid Plutus data
+// are left out.
+type TxData struct {
+	// Datums are the witness-set datums, keyed by datum hash.
+	Datums map[string]Data `json:"datums"`
+	// Redeemers are the redeemer data, keyed by "<purpose>:<index>", e.g. "spend:0".
+	Redeemers map[string]Data `json:"redeemers"`
+	// OutputDatums are the inline datums of the outputs, keyed by output index.
+	OutputDatums map[int]Data `json:"outputDatums"`
+}
+
+// redeemer is a redeemer as reported by Ogmios.
+type redeemer struct {
+	Validator struct {
+		Index   int    `json:"index"`
+		Purpose string `json:"purpose"`
+	} `json:"validator"`
+	Redeemer string `json:"redeemer"`
+}
+
+// DecodeTx decodes the datums, redeemers and inline output datums of a transaction.
+func DecodeTx(tx chainsync.Tx) *TxData {
+	data := &TxData{
+		Datums:       make(map[string]Data, len(tx.Datums)),
+		Redeemers:    make(map[string]Data),
+		OutputDatums: make(map[int]Data),
+	}
+
+	for hash, datum := range tx.Datums {
+		if value, err := DecodeHex(datum); err == nil {
+			data.Datums[hash] = value
+		}
+	}
+
+	for key, value := range RedeemerData(tx.Redeemers) {
+		data.Redeemers[key] = value
+	}
+
+	for i, out := range tx.Outputs {
+		if out.Datum == "" {
+			continue
+		}
+		if value, err := DecodeHex(out.Datum); err == nil {
+			data.OutputDatums[i] = value
+		}
+	}
+
+	return data
+}
+
+// RedeemerData decodes the data of each redeemer in the Ogmios redeemers JSON, keyed by
+// "<purpose>:<index>".
+func RedeemerData(redeemersJSON json.RawMessage) map[string]Data {
+	if len(redeemersJSON) == 0 {
+		return nil
+	}
+	var redeemers []redeemer
+	if err := json.Unmarshal(redeemersJSON, &redeemers); err != nil {
+		return nil
+	}
+
+	data := make(map[string]Data, len(redeemers))
+	for _, r := range redeemers {
+		if value, err := DecodeHex(r.Redeemer); err == nil {
+			data[RedeemerKey(r.Validator.Purpose, r.Validator.Index)] = value
+		}
+	}
+	return data
+}
+
+// DatumHash returns the hash of the datum of an output: the datum hash it carries, or the
+// Blake2b-256 hash of its inline datum. It is empty for outputs without datum.
+func DatumHash(out chainsync.TxOut) string {
+	if out.DatumHash != "" {
+		return out.DatumHash
+	}
+	datum, err := hex.DecodeString(out.Datum)
+	if err != nil || len(datum) == 0 {
+		return ""
+	}
+	hash := blake2b.Sum256(datum)
+	return hex.EncodeToString(hash[:])
+}
+
+// RedeemerKey returns the key of a redeemer, e.g. "spend:0".
+func RedeemerKey(purpose string, index int) string {
+	return fmt.Sprintf("%s:%d", purpose, index)
+}
@@ -0,0 +1,114 @@
+// internal/schemaregistry/client.go
+package schemaregistry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// contentType is the media type of the Confluent Schema Registry REST API.
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// errorCodeSubjectNotFound is returned by the registry for subjects without any version.
+const errorCodeSubjectNotFound = 40401
+
+// Client is a minimal client for a Confluent-compatible schema registry.
+type Client struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient creates a new schema registry client.
+func NewClient(baseURL, username, password string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type schemaRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+type registryError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func (e *registryError) Error() string {
+	return fmt.Sprintf("schema registry error %d: %s", e.ErrorCode, e.Message)
+}
+
+// CheckCompatibility reports whether the schema is compatible with the latest version
+// registered under the subject, according to the compatibility level of the registry.
+// A subject without any version is compatible with every schema.
+func (c *Client) CheckCompatibility(subject, schema string) (bool, error) {
+	var resp struct {
+		IsCompatible bool `json:"is_compatible"`
+	}
+	path := fmt.Sprintf("/compatibility/subjects/%s/versions/latest", url.PathEscape(subject))
+	err := c.post(path, schemaRequest{Schema: schema, SchemaType: "AVRO"}, &resp)
+	if regErr, ok := err.(*registryError); ok && regErr.ErrorCode == errorCodeSubjectNotFound {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return resp.IsCompatible, nil
+}
+
+// Register registers the schema under the subject and returns its global ID.
+// Registering a schema that already exists returns the existing ID.
+func (c *Client) Register(subject, schema string) (int, error) {
+	var resp struct {
+		ID int `json:"id"`
+	}
+	path := fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject))
+	if err := c.post(path, schemaRequest{Schema: schema, SchemaType: "AVRO"}, &resp); err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+func (c *Client) post(path string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		regErr := &registryError{ErrorCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(regErr); err != nil {
+			regErr.Message = resp.Status
+		}
+		return regErr
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
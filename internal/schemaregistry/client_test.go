@@ -0,0 +1,97 @@
+// internal/schemaregistry/client_test.go
+package schemaregistry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testSchema = `{"type": "record", "name": "Test", "fields": [{"name": "id", "type": "string"}]}`
+
+// registryStub serves a single endpoint of the registry API and checks the requests sent to it.
+func registryStub(t *testing.T, wantPath string, status int, response string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method is %s, want POST", r.Method)
+		}
+		if r.URL.EscapedPath() != wantPath {
+			t.Errorf("path is %s, want %s", r.URL.EscapedPath(), wantPath)
+		}
+		if got := r.Header.Get("Content-Type"); got != contentType {
+			t.Errorf("content type is %q, want %q", got, contentType)
+		}
+		if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "secret" {
+			t.Errorf("basic auth is %q/%q, want user/secret", user, password)
+		}
+		var req schemaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		if req.Schema != testSchema || req.SchemaType != "AVRO" {
+			t.Errorf("request is %+v, want the test schema with type AVRO", req)
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(server.URL+"/", "user", "secret", 5*time.Second)
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		want     bool
+		wantErr  bool
+	}{
+		{name: "compatible", status: http.StatusOK, response: `{"is_compatible": true}`, want: true},
+		{name: "incompatible", status: http.StatusOK, response: `{"is_compatible": false}`, want: false},
+		{name: "new subject", status: http.StatusNotFound, response: `{"error_code": 40401, "message": "Subject not found"}`, want: true},
+		{name: "unknown version", status: http.StatusNotFound, response: `{"error_code": 40402, "message": "Version not found"}`, wantErr: true},
+		{name: "invalid schema", status: http.StatusUnprocessableEntity, response: `{"error_code": 42201, "message": "Invalid schema"}`, wantErr: true},
+		{name: "server error", status: http.StatusInternalServerError, response: `oops`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := registryStub(t, "/compatibility/subjects/cardano.tx%2Fsync/versions/latest", tt.status, tt.response)
+			got, err := client.CheckCompatibility("cardano.tx/sync", testSchema)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("compatible = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	client := registryStub(t, "/subjects/cardano.txsync/versions", http.StatusOK, `{"id": 42}`)
+	id, err := client.Register("cardano.txsync", testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 42 {
+		t.Errorf("id = %d, want 42", id)
+	}
+}
+
+func TestRegisterIncompatible(t *testing.T) {
+	client := registryStub(t, "/subjects/cardano.txsync/versions", http.StatusConflict,
+		`{"error_code": 409, "message": "Schema being registered is incompatible with an earlier schema"}`)
+	_, err := client.Register("cardano.txsync", testSchema)
+	regErr, ok := err.(*registryError)
+	if !ok {
+		t.Fatalf("error = %v, want a registry error", err)
+	}
+	if regErr.ErrorCode != 409 {
+		t.Errorf("error code = %d, want 409", regErr.ErrorCode)
+	}
+}
@@ -0,0 +1,149 @@
+// internal/slottime/clock.go
+package slottime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SundaeSwap-finance/ogmigo"
+)
+
+// queryTimeout bounds the Ogmios queries made when refreshing the era summaries.
+const queryTimeout = 30 * time.Second
+
+// SlotTime is the wall-clock time and epoch position of a slot.
+type SlotTime struct {
+	Time      time.Time
+	Epoch     uint64
+	EpochSlot uint64
+}
+
+// era is an era summary with its bounds in slots and its start time.
+type era struct {
+	startSlot   uint64
+	endSlot     uint64 // exclusive, 0 if the era has no known end
+	startTime   time.Time
+	startEpoch  uint64
+	epochLength uint64
+	slotLength  time.Duration
+}
+
+// eraSource queries the system start and era summaries, from Ogmios.
+type eraSource interface {
+	StartTime(ctx context.Context) (string, error)
+	EraSummaries(ctx context.Context) (*ogmigo.EraHistory, error)
+}
+
+// Clock converts slots to wall-clock time using the era summaries and the system start of the
+// network, as reported by Ogmios. Era summaries only describe the chain up to a horizon, a safe
+// zone past the tip of the node, so they are refreshed when a block is beyond it.
+type Clock struct {
+	client eraSource
+
+	mu   sync.RWMutex
+	eras []era
+}
+
+// NewClock creates a clock and loads the era summaries.
+func NewClock(client *ogmigo.Client) (*Clock, error) {
+	c := &Clock{client: client}
+	if err := c.Refresh(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Refresh reloads the system start and era summaries from Ogmios.
+func (c *Clock) Refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	start, err := c.client.StartTime(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query system start: %w", err)
+	}
+	systemStart, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return fmt.Errorf("invalid system start %q: %w", start, err)
+	}
+
+	history, err := c.client.EraSummaries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query era summaries: %w", err)
+	}
+	if len(history.Summaries) == 0 {
+		return errors.New("no era summaries")
+	}
+
+	eras := make([]era, 0, len(history.Summaries))
+	for _, s := range history.Summaries {
+		eras = append(eras, era{
+			startSlot:   s.Start.Slot,
+			endSlot:     s.End.Slot,
+			startTime:   systemStart.Add(time.Duration(s.Start.Time.Seconds.Int64()) * time.Second),
+			startEpoch:  s.Start.Epoch,
+			epochLength: s.Parameters.EpochLength,
+			slotLength:  time.Duration(s.Parameters.SlotLength.Milliseconds.Int64()) * time.Millisecond,
+		})
+	}
+
+	c.mu.Lock()
+	c.eras = eras
+	c.mu.Unlock()
+	return nil
+}
+
+// BlockTime converts the slot of a block. The era summaries are refreshed once if the slot is
+// beyond their horizon, and an error is returned if it still is.
+func (c *Clock) BlockTime(slot uint64) (SlotTime, error) {
+	if t, ok := c.convert(slot, false); ok {
+		return t, nil
+	}
+	if err := c.Refresh(); err != nil {
+		return SlotTime{}, err
+	}
+	if t, ok := c.convert(slot, false); ok {
+		return t, nil
+	}
+	return SlotTime{}, fmt.Errorf("slot %d is beyond the era summaries horizon", slot)
+}
+
+// Time converts any slot, such as a validity interval bound. Slots beyond the horizon are
+// extrapolated with the parameters of the last known era.
+func (c *Clock) Time(slot uint64) time.Time {
+	return c.Extrapolate(slot).Time
+}
+
+// Extrapolate converts any slot like Time, with its epoch position. Past the horizon, it is only
+// wrong if an era change the node does not know about yet changes the slot or epoch length.
+func (c *Clock) Extrapolate(slot uint64) SlotTime {
+	t, _ := c.convert(slot, true)
+	return t
+}
+
+func (c *Clock) convert(slot uint64, extrapolate bool) (SlotTime, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i := len(c.eras) - 1; i >= 0; i-- {
+		e := c.eras[i]
+		if slot < e.startSlot {
+			continue
+		}
+		last := i == len(c.eras)-1
+		if e.endSlot != 0 && slot >= e.endSlot && !(last && extrapolate) {
+			return SlotTime{}, false
+		}
+		elapsed := slot - e.startSlot
+		t := SlotTime{Time: e.startTime.Add(time.Duration(elapsed) * e.slotLength).UTC()}
+		if e.epochLength > 0 {
+			t.Epoch = e.startEpoch + elapsed/e.epochLength
+			t.EpochSlot = elapsed % e.epochLength
+		}
+		return t, true
+	}
+	return SlotTime{}, false
+}
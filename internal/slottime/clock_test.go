@@ -0,0 +1,163 @@
+// internal/slottime/clock_test.go
+package slottime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SundaeSwap-finance/ogmigo"
+)
+
+// Mainnet: Byron has 20 second slots and 21600-slot epochs, Shelley and later eras 1 second slots
+// and 432000-slot epochs. Shelley starts at slot 4492800, epoch 208.
+const (
+	mainnetStart = "2017-09-23T21:44:51Z"
+	byron        = `{"start": {"time": {"seconds": 0}, "slot": 0, "epoch": 0},
+		"end": {"time": {"seconds": 89856000}, "slot": 4492800, "epoch": 208},
+		"parameters": {"epochLength": 21600, "slotLength": {"milliseconds": 20000}, "safeZone": 4320}}`
+	shelleyStart = 4492800
+)
+
+// shelley returns the summary of the Shelley era ending at a slot, its horizon.
+func shelley(endSlot uint64) string {
+	elapsed := endSlot - shelleyStart
+	return fmt.Sprintf(`{"start": {"time": {"seconds": 89856000}, "slot": 4492800, "epoch": 208},
+		"end": {"time": {"seconds": %d}, "slot": %d, "epoch": %d},
+		"parameters": {"epochLength": 432000, "slotLength": {"milliseconds": 1000}, "safeZone": 129600}}`,
+		89856000+elapsed, endSlot, 208+elapsed/432000)
+}
+
+// fakeEras serves era summaries, the next ones on each query.
+type fakeEras struct {
+	t       *testing.T
+	results []string
+	queries int
+}
+
+func (f *fakeEras) StartTime(ctx context.Context) (string, error) {
+	return mainnetStart, nil
+}
+
+func (f *fakeEras) EraSummaries(ctx context.Context) (*ogmigo.EraHistory, error) {
+	result := f.results[min(f.queries, len(f.results)-1)]
+	f.queries++
+	var history ogmigo.EraHistory
+	if err := json.Unmarshal([]byte(result), &history.Summaries); err != nil {
+		f.t.Fatal(err)
+	}
+	return &history, nil
+}
+
+func newTestClock(t *testing.T, results ...string) (*Clock, *fakeEras) {
+	t.Helper()
+	source := &fakeEras{t: t, results: results}
+	c := &Clock{client: source}
+	if err := c.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	return c, source
+}
+
+func eras(summaries ...string) string {
+	out := "["
+	for i, s := range summaries {
+		if i > 0 {
+			out += ","
+		}
+		out += s
+	}
+	return out + "]"
+}
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestBlockTimeEraBoundaries(t *testing.T) {
+	c, _ := newTestClock(t, eras(byron, shelley(70000000)))
+
+	tests := []struct {
+		name      string
+		slot      uint64
+		want      string
+		epoch     uint64
+		epochSlot uint64
+	}{
+		{"genesis", 0, "2017-09-23T21:44:51Z", 0, 0},
+		{"second Byron epoch", 21600, "2017-09-28T21:44:51Z", 1, 0},
+		{"last Byron slot", shelleyStart - 1, "2020-07-29T21:44:31Z", 207, 21599},
+		{"first Shelley slot", shelleyStart, "2020-07-29T21:44:51Z", 208, 0},
+		{"Shelley", 65000000, "2022-06-30T05:18:11Z", 348, 27200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.BlockTime(tt.slot)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Time.Equal(mustTime(t, tt.want)) || got.Epoch != tt.epoch || got.EpochSlot != tt.epochSlot {
+				t.Errorf("slot %d is %s, epoch %d slot %d, want %s, epoch %d slot %d",
+					tt.slot, got.Time.Format(time.RFC3339), got.Epoch, got.EpochSlot, tt.want, tt.epoch, tt.epochSlot)
+			}
+		})
+	}
+}
+
+func TestBlockTimeRefreshesPastHorizon(t *testing.T) {
+	c, source := newTestClock(t, eras(byron, shelley(70000000)), eras(byron, shelley(70100000)))
+
+	if _, err := c.BlockTime(69999999); err != nil || source.queries != 1 {
+		t.Fatalf("a slot within the horizon: error %v after %d queries", err, source.queries)
+	}
+	got, err := c.BlockTime(70000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source.queries != 2 {
+		t.Errorf("era summaries queried %d times, want a refresh", source.queries)
+	}
+	if want := mustTime(t, "2022-06-30T05:18:11Z").Add(5000000 * time.Second); !got.Time.Equal(want) {
+		t.Errorf("slot 70000000 is %s, want %s", got.Time, want)
+	}
+
+	// Still past the horizon after the refresh.
+	if _, err := c.BlockTime(70100000); err == nil {
+		t.Error("a slot past the refreshed horizon was converted")
+	}
+}
+
+func TestTimeExtrapolatesPastHorizon(t *testing.T) {
+	c, source := newTestClock(t, eras(byron, shelley(70000000)))
+
+	// Validity bounds far past the horizon use the last era's slot length, without a refresh.
+	got := c.Time(80000000)
+	if want := mustTime(t, "2022-06-30T05:18:11Z").Add(15000000 * time.Second); !got.Equal(want) {
+		t.Errorf("slot 80000000 is %s, want %s", got, want)
+	}
+	if source.queries != 1 {
+		t.Errorf("era summaries queried %d times, want no refresh", source.queries)
+	}
+	if got := c.Extrapolate(80000000); got.Epoch != 382 || got.EpochSlot != 339200 {
+		t.Errorf("slot 80000000 is in epoch %d at slot %d, want epoch 382 at slot 339200", got.Epoch, got.EpochSlot)
+	}
+
+	// Past the end of a closed era, the next era applies.
+	if got, want := c.Time(shelleyStart+1), mustTime(t, "2020-07-29T21:44:52Z"); !got.Equal(want) {
+		t.Errorf("slot %d is %s, want %s", shelleyStart+1, got, want)
+	}
+}
+
+func TestRefreshWithoutSummaries(t *testing.T) {
+	c := &Clock{client: &fakeEras{t: t, results: []string{"[]"}}}
+	if err := c.Refresh(); err == nil {
+		t.Error("empty era summaries were accepted")
+	}
+}
@@ -3,13 +3,14 @@ package storage
 
 import (
 	"cardano-tx-sync/config"
+	"cardano-tx-sync/internal/metrics"
 	"cardano-tx-sync/internal/model"
 	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/patrickmn/go-cache"
 )
 
@@ -63,27 +64,122 @@ func (s *PostgresStorage) initSchema() error {
 		UNIQUE(type, key, topic)
 	);
 
+	ALTER TABLE mappings ADD COLUMN IF NOT EXISTS encoder_options JSONB;
+
+	CREATE TABLE IF NOT EXISTS blueprints (
+		id SERIAL PRIMARY KEY,
+		name TEXT NOT NULL UNIQUE,
+		blueprint JSONB NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS tracked_outputs (
+		tx_id TEXT NOT NULL,
+		output_index INTEGER NOT NULL,
+		address TEXT NOT NULL,
+		value JSONB NOT NULL,
+		datum_hash TEXT,
+		slot BIGINT NOT NULL,
+		spent_slot BIGINT,
+		PRIMARY KEY (tx_id, output_index)
+	);
+
+	-- Address mappings whose unspent outputs were loaded from the ledger state when added. Spends
+	-- of the address are fully resolved in blocks after the slot of the ledger snapshot.
+	CREATE TABLE IF NOT EXISTS backfills (
+		mapping_id INTEGER PRIMARY KEY REFERENCES mappings(id) ON DELETE CASCADE,
+		slot BIGINT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE INDEX IF NOT EXISTS tracked_outputs_slot_idx ON tracked_outputs (slot);
+	CREATE INDEX IF NOT EXISTS tracked_outputs_spent_slot_idx ON tracked_outputs (spent_slot);
+
+	ALTER TABLE mappings ADD COLUMN IF NOT EXISTS blueprint_id INTEGER REFERENCES blueprints(id) ON DELETE RESTRICT;
+
+	ALTER TABLE mappings ADD COLUMN IF NOT EXISTS partition_key TEXT NOT NULL DEFAULT 'none';
+
 	CREATE TABLE IF NOT EXISTS checkpoints (
 		id SERIAL PRIMARY KEY,
 		slot BIGINT NOT NULL,
 		hash TEXT NOT NULL,
 		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 	);
+
+	CREATE TABLE IF NOT EXISTS settings (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS audit_log (
+		id BIGSERIAL PRIMARY KEY,
+		client_ip TEXT NOT NULL,
+		claimed_actor TEXT, -- X-Actor request header, not authenticated
+		action TEXT NOT NULL, -- 'create', 'update', 'delete', 'set_start_point', 'pause', 'resume', 'redrive'
+		entity_type TEXT NOT NULL, -- 'mapping', 'sync_point', 'syncer', 'blueprint', 'dead_letter'
+		entity_id TEXT,
+		before_value JSONB,
+		after_value JSONB,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity_type, entity_id);
+
+	CREATE TABLE IF NOT EXISTS dead_letters (
+		id BIGSERIAL PRIMARY KEY,
+		stage TEXT NOT NULL, -- 'encode', 'publish'
+		tx_id TEXT NOT NULL,
+		slot BIGINT NOT NULL,
+		block_hash TEXT NOT NULL,
+		mapping_id INTEGER REFERENCES mappings(id) ON DELETE SET NULL,
+		encoder TEXT NOT NULL,
+		topic TEXT NOT NULL,
+		error TEXT NOT NULL,
+		message JSONB NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		redriving_at TIMESTAMPTZ, -- set while a re-drive is in progress, so that concurrent re-drives do not publish twice
+		redriven_at TIMESTAMPTZ
+	);
+
+	CREATE INDEX IF NOT EXISTS dead_letters_tx_id_idx ON dead_letters (tx_id);
+	CREATE INDEX IF NOT EXISTS dead_letters_slot_idx ON dead_letters (slot);
+
+	-- The audit log is append-only: silently discard any update or delete.
+	CREATE OR REPLACE RULE audit_log_no_update AS ON UPDATE TO audit_log DO INSTEAD NOTHING;
+	CREATE OR REPLACE RULE audit_log_no_delete AS ON DELETE TO audit_log DO INSTEAD NOTHING;
 	`
 	_, err := s.db.Exec(schema)
 	return err
 }
 
+// Ping checks the database connection.
+func (s *PostgresStorage) Ping() error {
+	return s.db.Ping()
+}
+
 // Close closes the database connection.
 func (s *PostgresStorage) Close() error {
 	return s.db.Close()
 }
 
-// AddMapping adds a new mapping to the database.
-func (s *PostgresStorage) AddMapping(mapping model.Mapping) (int, error) {
+// mappingColumns are the mapping columns selected into model.Mapping. Missing encoder options
+// are read as an empty string, as a NULL cannot be scanned into json.RawMessage.
+const mappingColumns = `id, group_id, type, key, topic, encoder, COALESCE(encoder_options::text, '') AS encoder_options, blueprint_id, partition_key`
+
+// AddMapping adds a new mapping to the database, with the audit entry built for its ID.
+func (s *PostgresStorage) AddMapping(mapping model.Mapping, audit func(id int) model.AuditEntry) (int, error) {
 	var id int
-	query := `INSERT INTO mappings (group_id, type, key, topic, encoder) VALUES ($1, $2, $3, $4, $5) RETURNING id`
-	err := s.db.QueryRow(query, mapping.GroupID, mapping.Type, mapping.Key, mapping.Topic, mapping.Encoder).Scan(&id)
+	err := s.withAudit(func(tx *sqlx.Tx) (model.AuditEntry, error) {
+		query := `INSERT INTO mappings (group_id, type, key, topic, encoder, encoder_options, blueprint_id, partition_key) VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7, $8) RETURNING id`
+		err := tx.QueryRow(query, mapping.GroupID, mapping.Type, mapping.Key, mapping.Topic, mapping.Encoder,
+			nullableJSON(mapping.EncoderOptions), mapping.BlueprintID, mapping.PartitionKey).Scan(&id)
+		if err != nil {
+			return model.AuditEntry{}, err
+		}
+		return audit(id), nil
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -91,10 +187,62 @@ func (s *PostgresStorage) AddMapping(mapping model.Mapping) (int, error) {
 	return id, nil
 }
 
-// RemoveMapping removes a mapping from the database.
-func (s *PostgresStorage) RemoveMapping(id int) error {
-	query := `DELETE FROM mappings WHERE id = $1`
-	_, err := s.db.Exec(query, id)
+// GetMapping retrieves a single mapping by ID. It returns nil if no mapping exists.
+func (s *PostgresStorage) GetMapping(id int) (*model.Mapping, error) {
+	var mapping model.Mapping
+	query := "SELECT " + mappingColumns + ` FROM mappings WHERE id = $1`
+	err := s.db.Get(&mapping, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mapping, nil
+}
+
+// UpdateMapping replaces an existing mapping, with the audit entry built from its previous value.
+// It returns ErrMappingNotFound if the mapping does not exist.
+func (s *PostgresStorage) UpdateMapping(mapping model.Mapping, audit func(before model.Mapping) model.AuditEntry) error {
+	err := s.withAudit(func(tx *sqlx.Tx) (model.AuditEntry, error) {
+		// The row is locked, so that the audited previous value is the one replaced.
+		var before model.Mapping
+		err := tx.Get(&before, "SELECT "+mappingColumns+` FROM mappings WHERE id = $1 FOR UPDATE`, mapping.ID)
+		if err == sql.ErrNoRows {
+			return model.AuditEntry{}, ErrMappingNotFound
+		}
+		if err != nil {
+			return model.AuditEntry{}, err
+		}
+		query := `UPDATE mappings SET group_id = $1, type = $2, key = $3, topic = $4, encoder = $5, encoder_options = $6::jsonb, blueprint_id = $7, partition_key = $8 WHERE id = $9`
+		_, err = tx.Exec(query, mapping.GroupID, mapping.Type, mapping.Key, mapping.Topic, mapping.Encoder,
+			nullableJSON(mapping.EncoderOptions), mapping.BlueprintID, mapping.PartitionKey, mapping.ID)
+		if err != nil {
+			return model.AuditEntry{}, err
+		}
+		return audit(before), nil
+	})
+	if err != nil {
+		return err
+	}
+	s.cache.Flush() // Invalidate cache
+	return nil
+}
+
+// RemoveMapping removes a mapping from the database, with the audit entry built from the removed
+// row. It returns ErrMappingNotFound if the mapping does not exist.
+func (s *PostgresStorage) RemoveMapping(id int, audit func(before model.Mapping) model.AuditEntry) error {
+	err := s.withAudit(func(tx *sqlx.Tx) (model.AuditEntry, error) {
+		var before model.Mapping
+		err := tx.Get(&before, `DELETE FROM mappings WHERE id = $1 RETURNING `+mappingColumns, id)
+		if err == sql.ErrNoRows {
+			return model.AuditEntry{}, ErrMappingNotFound
+		}
+		if err != nil {
+			return model.AuditEntry{}, err
+		}
+		return audit(before), nil
+	})
 	if err != nil {
 		return err
 	}
@@ -106,11 +254,13 @@ func (s *PostgresStorage) RemoveMapping(id int) error {
 func (s *PostgresStorage) GetMappingsFor(mappingType, key string) ([]model.Mapping, error) {
 	cacheKey := fmt.Sprintf("mappings:%s:%s", mappingType, key)
 	if cached, found := s.cache.Get(cacheKey); found {
+		metrics.MappingCacheLookups.WithLabelValues("hit").Inc()
 		return cached.([]model.Mapping), nil
 	}
+	metrics.MappingCacheLookups.WithLabelValues("miss").Inc()
 
 	var mappings []model.Mapping
-	query := `SELECT id, group_id, type, key, topic, encoder FROM mappings WHERE type = $1 AND key = $2`
+	query := "SELECT " + mappingColumns + ` FROM mappings WHERE type = $1 AND key = $2`
 	err := s.db.Select(&mappings, query, mappingType, key)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
@@ -120,6 +270,56 @@ func (s *PostgresStorage) GetMappingsFor(mappingType, key string) ([]model.Mappi
 	return mappings, nil
 }
 
+// AddBlueprint stores a new blueprint, with the audit entry built for its ID.
+func (s *PostgresStorage) AddBlueprint(blueprint model.Blueprint, audit func(id int) model.AuditEntry) (int, error) {
+	var id int
+	err := s.withAudit(func(tx *sqlx.Tx) (model.AuditEntry, error) {
+		query := `INSERT INTO blueprints (name, blueprint) VALUES ($1, $2::jsonb) RETURNING id`
+		if err := tx.QueryRow(query, blueprint.Name, string(blueprint.Blueprint)).Scan(&id); err != nil {
+			return model.AuditEntry{}, err
+		}
+		return audit(id), nil
+	})
+	return id, err
+}
+
+// GetBlueprint retrieves a blueprint by ID. It returns nil if no blueprint exists.
+func (s *PostgresStorage) GetBlueprint(id int) (*model.Blueprint, error) {
+	var blueprint model.Blueprint
+	query := `SELECT id, name, blueprint, created_at FROM blueprints WHERE id = $1`
+	err := s.db.Get(&blueprint, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &blueprint, nil
+}
+
+// ListBlueprints lists the blueprints, without their content.
+func (s *PostgresStorage) ListBlueprints() ([]model.Blueprint, error) {
+	var blueprints []model.Blueprint
+	query := `SELECT id, name, created_at FROM blueprints ORDER BY id`
+	err := s.db.Select(&blueprints, query)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return blueprints, nil
+}
+
+// RemoveBlueprint removes a blueprint. It returns ErrBlueprintInUse if a mapping references it.
+func (s *PostgresStorage) RemoveBlueprint(id int, audit model.AuditEntry) error {
+	err := s.withAudit(func(tx *sqlx.Tx) (model.AuditEntry, error) {
+		_, err := tx.Exec(`DELETE FROM blueprints WHERE id = $1`, id)
+		return audit, err
+	})
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+		return ErrBlueprintInUse
+	}
+	return err
+}
+
 // SaveCheckpoint saves a new checkpoint.
 func (s *PostgresStorage) SaveCheckpoint(checkpoint model.Checkpoint, maxCheckpoints int) error {
 	tx, err := s.db.Beginx()
@@ -160,16 +360,322 @@ func (s *PostgresStorage) GetLatestCheckpoints(limit int) ([]model.Checkpoint, e
 	return checkpoints, nil
 }
 
-// ClearCheckpoints removes all checkpoints.
-func (s *PostgresStorage) ClearCheckpoints() error {
-	query := `DELETE FROM checkpoints`
-	_, err := s.db.Exec(query)
-	return err
+// ClearCheckpoints removes all checkpoints, with the audit entry of the change of start point.
+func (s *PostgresStorage) ClearCheckpoints(audit model.AuditEntry) error {
+	return s.withAudit(func(tx *sqlx.Tx) (model.AuditEntry, error) {
+		_, err := tx.Exec(`DELETE FROM checkpoints`)
+		return audit, err
+	})
 }
 
-// Rollback deletes checkpoints after a given slot.
+// Rollback deletes checkpoints and tracked outputs after a given slot, and unspends the tracked
+// outputs spent after it.
 func (s *PostgresStorage) Rollback(slot uint64) error {
-	query := `DELETE FROM checkpoints WHERE slot > $1`
-	_, err := s.db.Exec(query, slot)
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	queries := []string{
+		`DELETE FROM checkpoints WHERE slot > $1`,
+		`DELETE FROM tracked_outputs WHERE slot > $1`,
+		`UPDATE tracked_outputs SET spent_slot = NULL WHERE spent_slot > $1`,
+		// Rolled back transactions are no longer on chain and must not be re-driven.
+		`DELETE FROM dead_letters WHERE slot > $1 AND redriven_at IS NULL`,
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query, slot); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ResolveOutputs returns the tracked outputs among the given references, spent or not.
+func (s *PostgresStorage) ResolveOutputs(refs []model.OutputRef) ([]model.TrackedOutput, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	txIDs := make([]string, len(refs))
+	indexes := make([]int64, len(refs))
+	for i, ref := range refs {
+		txIDs[i], indexes[i] = ref.TxID, int64(ref.Index)
+	}
+
+	var outputs []model.TrackedOutput
+	query := `
+		SELECT o.tx_id, o.output_index, o.address, o.value, COALESCE(o.datum_hash, '') AS datum_hash, o.slot
+		FROM tracked_outputs o
+		JOIN unnest($1::text[], $2::integer[]) AS r (tx_id, output_index)
+			ON o.tx_id = r.tx_id AND o.output_index = r.output_index`
+	err := s.db.Select(&outputs, query, pq.Array(txIDs), pq.Array(indexes))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// SaveBlockOutputs records the tracked outputs created in a block and marks the spent ones.
+// It is idempotent, so that a block can be processed again after a restart.
+func (s *PostgresStorage) SaveBlockOutputs(created []model.TrackedOutput, spent []model.OutputRef, slot uint64) error {
+	if len(created) == 0 && len(spent) == 0 {
+		return nil
+	}
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	insert := `
+		INSERT INTO tracked_outputs (tx_id, output_index, address, value, datum_hash, slot)
+		VALUES ($1, $2, $3, $4::jsonb, NULLIF($5, ''), $6)
+		ON CONFLICT (tx_id, output_index) DO NOTHING`
+	for _, out := range created {
+		if _, err := tx.Exec(insert, out.TxID, out.Index, out.Address, string(out.Value), out.DatumHash, out.Slot); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	spend := `UPDATE tracked_outputs SET spent_slot = $3 WHERE tx_id = $1 AND output_index = $2`
+	for _, ref := range spent {
+		if _, err := tx.Exec(spend, ref.TxID, ref.Index, slot); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SaveBackfill records the unspent outputs of a mapping's address taken from the ledger state at
+// a slot. The outputs are stored at slot 0, so that they are kept through rollbacks.
+func (s *PostgresStorage) SaveBackfill(mappingID int, outputs []model.TrackedOutput, slot uint64) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	insert := `
+		INSERT INTO tracked_outputs (tx_id, output_index, address, value, datum_hash, slot)
+		VALUES ($1, $2, $3, $4::jsonb, NULLIF($5, ''), 0)
+		ON CONFLICT (tx_id, output_index) DO NOTHING`
+	for _, out := range outputs {
+		if _, err := tx.Exec(insert, out.TxID, out.Index, out.Address, string(out.Value), out.DatumHash); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	upsert := `
+		INSERT INTO backfills (mapping_id, slot) VALUES ($1, $2)
+		ON CONFLICT (mapping_id) DO UPDATE SET slot = EXCLUDED.slot, created_at = NOW()`
+	if _, err := tx.Exec(upsert, mappingID, slot); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.cache.Flush() // Invalidate cache
+	return nil
+}
+
+// GetBackfillSlot returns the earliest backfill slot of the mappings for a given mapping type and
+// key. The boolean is false if none of them was backfilled.
+func (s *PostgresStorage) GetBackfillSlot(mappingType, key string) (uint64, bool, error) {
+	cacheKey := fmt.Sprintf("backfill:%s:%s", mappingType, key)
+	if cached, found := s.cache.Get(cacheKey); found {
+		slot := cached.(sql.NullInt64)
+		return uint64(slot.Int64), slot.Valid, nil
+	}
+
+	var slot sql.NullInt64
+	query := `
+		SELECT MIN(b.slot) FROM backfills b
+		JOIN mappings m ON m.id = b.mapping_id
+		WHERE m.type = $1 AND m.key = $2`
+	if err := s.db.Get(&slot, query, mappingType, key); err != nil {
+		return 0, false, err
+	}
+
+	s.cache.Set(cacheKey, slot, cache.DefaultExpiration)
+	return uint64(slot.Int64), slot.Valid, nil
+}
+
+// GetMappingsToBackfill returns the address mappings, other than wildcards, that were not
+// backfilled.
+func (s *PostgresStorage) GetMappingsToBackfill() ([]model.Mapping, error) {
+	var mappings []model.Mapping
+	query := "SELECT " + mappingColumns + ` FROM mappings
+		WHERE type = $1 AND key <> '*' AND id NOT IN (SELECT mapping_id FROM backfills)
+		ORDER BY id`
+	err := s.db.Select(&mappings, query, string(model.MappingTypeAddress))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// PruneSpentOutputs deletes the tracked outputs spent before a given slot.
+func (s *PostgresStorage) PruneSpentOutputs(beforeSlot uint64) error {
+	query := `DELETE FROM tracked_outputs WHERE spent_slot < $1`
+	_, err := s.db.Exec(query, beforeSlot)
 	return err
 }
+
+// GetSetting retrieves a persisted setting. The boolean is false if the setting is not set.
+func (s *PostgresStorage) GetSetting(key string) (string, bool, error) {
+	var value string
+	query := `SELECT value FROM settings WHERE key = $1`
+	err := s.db.Get(&value, query, key)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// SetSetting creates or replaces a persisted setting, with its audit entry.
+func (s *PostgresStorage) SetSetting(key, value string, audit model.AuditEntry) error {
+	return s.withAudit(func(tx *sqlx.Tx) (model.AuditEntry, error) {
+		query := `
+			INSERT INTO settings (key, value) VALUES ($1, $2)
+			ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()`
+		_, err := tx.Exec(query, key, value)
+		return audit, err
+	})
+}
+
+// withAudit runs a change and appends its audit entry in the same transaction, so that a change
+// is never applied without being audited.
+func (s *PostgresStorage) withAudit(change func(tx *sqlx.Tx) (model.AuditEntry, error)) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	entry, err := change(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := `INSERT INTO audit_log (client_ip, claimed_actor, action, entity_type, entity_id, before_value, after_value)
+		VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7::jsonb)`
+	_, err = tx.Exec(query, entry.ClientIP, entry.ClaimedActor, entry.Action, entry.EntityType, entry.EntityID,
+		nullableJSON(entry.Before), nullableJSON(entry.After))
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetAuditEntries retrieves audit entries, newest first.
+func (s *PostgresStorage) GetAuditEntries(filter model.AuditFilter) ([]model.AuditEntry, error) {
+	var entries []model.AuditEntry
+	query := `
+		SELECT id, client_ip, claimed_actor, action, entity_type, entity_id, before_value, after_value, created_at
+		FROM audit_log
+		WHERE ($1 = '' OR entity_type = $1) AND ($2 = '' OR entity_id = $2)
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4`
+	err := s.db.Select(&entries, query, filter.EntityType, filter.EntityID, filter.Limit, filter.Offset)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return entries, nil
+}
+
+const deadLetterColumns = `id, stage, tx_id, slot, block_hash, mapping_id, encoder, topic, error, message, attempts, created_at, redriven_at`
+
+// AddDeadLetter stores a message that could not be encoded or published. The mapping may have been
+// removed while its block was processed; the dead letter is then stored without it.
+func (s *PostgresStorage) AddDeadLetter(entry model.DeadLetter) (int64, error) {
+	var id int64
+	query := `INSERT INTO dead_letters (stage, tx_id, slot, block_hash, mapping_id, encoder, topic, error, message)
+		VALUES ($1, $2, $3, $4, (SELECT id FROM mappings WHERE id = $5), $6, $7, $8, $9::jsonb) RETURNING id`
+	err := s.db.QueryRow(query, entry.Stage, entry.TxID, entry.Slot, entry.BlockHash, entry.MappingID,
+		entry.Encoder, entry.Topic, entry.Error, string(entry.Message)).Scan(&id)
+	return id, err
+}
+
+// GetDeadLetter retrieves a dead letter by its ID. It returns nil if there is none.
+func (s *PostgresStorage) GetDeadLetter(id int64) (*model.DeadLetter, error) {
+	var entry model.DeadLetter
+	query := "SELECT " + deadLetterColumns + ` FROM dead_letters WHERE id = $1`
+	err := s.db.Get(&entry, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetDeadLetters retrieves dead letters, newest first.
+func (s *PostgresStorage) GetDeadLetters(filter model.DeadLetterFilter) ([]model.DeadLetter, error) {
+	var entries []model.DeadLetter
+	query := "SELECT " + deadLetterColumns + `
+		FROM dead_letters
+		WHERE ($1 = '' OR tx_id = $1) AND (NOT $2 OR redriven_at IS NULL)
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4`
+	err := s.db.Select(&entries, query, filter.TxID, filter.Pending, filter.Limit, filter.Offset)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// redriveClaimTimeout is how long a re-drive claim holds, after which a re-drive interrupted by a
+// crash can be attempted again.
+const redriveClaimTimeout = "5 minutes"
+
+// ClaimDeadLetter marks a dead letter as being re-driven and returns it. It returns nil if the
+// dead letter does not exist, was already re-driven or is being re-driven.
+func (s *PostgresStorage) ClaimDeadLetter(id int64) (*model.DeadLetter, error) {
+	var entry model.DeadLetter
+	query := `
+		UPDATE dead_letters SET redriving_at = NOW()
+		WHERE id = $1 AND redriven_at IS NULL
+			AND (redriving_at IS NULL OR redriving_at < NOW() - $2::interval)
+		RETURNING ` + deadLetterColumns
+	err := s.db.Get(&entry, query, id, redriveClaimTimeout)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// SaveRedriveAttempt records a re-drive of a claimed dead letter, with its audit entry: its time if
+// it succeeded, its error otherwise. The claim is released either way.
+func (s *PostgresStorage) SaveRedriveAttempt(id int64, redriveErr error, audit model.AuditEntry) error {
+	return s.withAudit(func(tx *sqlx.Tx) (model.AuditEntry, error) {
+		var err error
+		if redriveErr != nil {
+			_, err = tx.Exec(`UPDATE dead_letters SET attempts = attempts + 1, error = $1, redriving_at = NULL WHERE id = $2`, redriveErr.Error(), id)
+		} else {
+			_, err = tx.Exec(`UPDATE dead_letters SET attempts = attempts + 1, redriven_at = NOW(), redriving_at = NULL WHERE id = $1`, id)
+		}
+		return audit, err
+	})
+}
+
+// nullableJSON converts raw JSON into a value that lib/pq stores as JSONB (or NULL when empty).
+func nullableJSON(raw []byte) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
@@ -1,16 +1,48 @@
 // internal/storage/storage.go
 package storage
 
-import "cardano-tx-sync/internal/model"
+import (
+	"cardano-tx-sync/internal/model"
+	"errors"
+)
 
-// Storage defines the interface for database operations.
+// ErrBlueprintInUse is returned when removing a blueprint that is referenced by a mapping.
+var ErrBlueprintInUse = errors.New("blueprint is referenced by a mapping")
+
+// ErrMappingNotFound is returned when updating or removing a mapping that does not exist.
+var ErrMappingNotFound = errors.New("mapping not found")
+
+// Storage defines the interface for database operations. Configuration changes and dead letter
+// re-drives take their audit entry, which is written in the same transaction. Entries that depend on the stored mapping,
+// its new ID or its previous value, are built from it within the transaction.
 type Storage interface {
-	AddMapping(mapping model.Mapping) (int, error)
-	RemoveMapping(id int) error
+	AddMapping(mapping model.Mapping, audit func(id int) model.AuditEntry) (int, error)
+	GetMapping(id int) (*model.Mapping, error)
+	UpdateMapping(mapping model.Mapping, audit func(before model.Mapping) model.AuditEntry) error
+	RemoveMapping(id int, audit func(before model.Mapping) model.AuditEntry) error
 	GetMappingsFor(mappingType, key string) ([]model.Mapping, error)
+	AddBlueprint(blueprint model.Blueprint, audit func(id int) model.AuditEntry) (int, error)
+	GetBlueprint(id int) (*model.Blueprint, error)
+	ListBlueprints() ([]model.Blueprint, error)
+	RemoveBlueprint(id int, audit model.AuditEntry) error
 	SaveCheckpoint(checkpoint model.Checkpoint, maxCheckpoints int) error
 	GetLatestCheckpoints(limit int) ([]model.Checkpoint, error)
-	ClearCheckpoints() error
+	ClearCheckpoints(audit model.AuditEntry) error
 	Rollback(slot uint64) error
+	ResolveOutputs(refs []model.OutputRef) ([]model.TrackedOutput, error)
+	SaveBlockOutputs(created []model.TrackedOutput, spent []model.OutputRef, slot uint64) error
+	PruneSpentOutputs(beforeSlot uint64) error
+	SaveBackfill(mappingID int, outputs []model.TrackedOutput, slot uint64) error
+	GetBackfillSlot(mappingType, key string) (uint64, bool, error)
+	GetMappingsToBackfill() ([]model.Mapping, error)
+	GetSetting(key string) (string, bool, error)
+	SetSetting(key, value string, audit model.AuditEntry) error
+	GetAuditEntries(filter model.AuditFilter) ([]model.AuditEntry, error)
+	AddDeadLetter(entry model.DeadLetter) (int64, error)
+	GetDeadLetter(id int64) (*model.DeadLetter, error)
+	GetDeadLetters(filter model.DeadLetterFilter) ([]model.DeadLetter, error)
+	ClaimDeadLetter(id int64) (*model.DeadLetter, error)
+	SaveRedriveAttempt(id int64, redriveErr error, audit model.AuditEntry) error
+	Ping() error
 	Close() error
 }
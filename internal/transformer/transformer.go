@@ -2,19 +2,32 @@
 package transformer
 
 import (
+	"cardano-tx-sync/internal/address"
 	"cardano-tx-sync/internal/model"
 	"cardano-tx-sync/internal/pb"
+	"cardano-tx-sync/internal/plutus"
 	"cardano-tx-sync/internal/utils"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
 	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
 	"github.com/gogo/protobuf/types"
 )
 
+// Options controls how a transaction is transformed.
+type Options struct {
+	// LegacyInt64Quantities publishes asset quantities in the deprecated int64 fields instead of
+	// the lossless decimal string fields. Quantities above 2^63-1 wrap around in this format.
+	LegacyInt64Quantities bool
+	// DecodePlutusData adds the datums, redeemers and inline output datums decoded from Plutus
+	// data CBOR to detailed-schema JSON.
+	DecodePlutusData bool
+}
+
 // ToCardanoTransaction converts a model.TxnMessage to a pb.CardanoTransaction.
-func ToCardanoTransaction(txMsg model.TxnMessage) (*pb.CardanoTransaction, error) {
+func ToCardanoTransaction(txMsg model.TxnMessage, opts Options) (*pb.CardanoTransaction, error) {
 	tx := txMsg.Tx
 	block := txMsg.Block
 
@@ -28,7 +41,7 @@ func ToCardanoTransaction(txMsg model.TxnMessage) (*pb.CardanoTransaction, error
 	// Refer to test data:
 	// https://github.com/SundaeSwap-finance/ogmigo/blob/6211ee30eaa35d0955673a117cb0f3bf834044c0/ouroboros/chainsync/types_test.go#L878
 
-	txBody, err := transformBody(tx)
+	txBody, err := transformBody(tx, opts)
 	if err != nil {
 		// have logged inside transformBody()
 		// fmt.Printf("[Transformer] cannot parse body to proto message, datums: %v, error: %v\n", tx.Datums, err)
@@ -69,7 +82,7 @@ func ToCardanoTransaction(txMsg model.TxnMessage) (*pb.CardanoTransaction, error
 	}
 
 	cardanoTx := &pb.CardanoTransaction{
-		TransactionID: tx.ID,
+		TransactionId: tx.ID,
 		HeaderHash:    block.Hash,
 		Slot:          block.Slot,
 		Cbor:          tx.CBOR,
@@ -81,41 +94,98 @@ func ToCardanoTransaction(txMsg model.TxnMessage) (*pb.CardanoTransaction, error
 		Proposals:     proposals,
 		Votes:         votes,
 		Signatures:    signatures,
+		TypedDataJson: string(txMsg.TypedData),
+		BlockTime:     transformTime(&block.Time),
+		Epoch:         block.Epoch,
+		EpochSlot:     block.EpochSlot,
+		BlockHeight:   block.Height,
+		IssuerPoolId:  block.IssuerPoolID,
+		BlockSize:     block.Size,
+		BlockTxCount:  int32(block.TxCount),
+		TxIndex:       int32(txMsg.TxIndex),
+	}
+	txBody.ValidFrom = transformTime(txMsg.ValidFrom)
+	txBody.ValidUntil = transformTime(txMsg.ValidUntil)
+
+	if opts.DecodePlutusData {
+		addPlutusData(cardanoTx, tx)
 	}
 
 	return cardanoTx, nil
 }
 
-func transformBody(tx chainsync.Tx) (*pb.TxBody, error) {
-	outputs, err := transformOutputs(tx.Outputs)
+// addPlutusData sets the decoded Plutus data of the transaction. Data that cannot be decoded is left out.
+func addPlutusData(cardanoTx *pb.CardanoTransaction, tx chainsync.Tx) {
+	data := plutus.DecodeTx(tx)
+
+	cardanoTx.DatumsJson = make(map[string]string, len(data.Datums))
+	for hash, datum := range data.Datums {
+		if raw, err := json.Marshal(datum); err == nil {
+			cardanoTx.DatumsJson[hash] = string(raw)
+		}
+	}
+
+	cardanoTx.RedeemersJson = make(map[string]string, len(data.Redeemers))
+	for key, redeemer := range data.Redeemers {
+		if raw, err := json.Marshal(redeemer); err == nil {
+			cardanoTx.RedeemersJson[key] = string(raw)
+		}
+	}
+
+	for i, datum := range data.OutputDatums {
+		if raw, err := json.Marshal(datum); err == nil && i < len(cardanoTx.Body.Outputs) {
+			cardanoTx.Body.Outputs[i].DatumJson = string(raw)
+		}
+	}
+}
+
+func transformBody(tx chainsync.Tx, opts Options) (*pb.TxBody, error) {
+	outputs, err := transformOutputs(tx.Outputs, opts)
 	if err != nil {
 		fmt.Printf("[Transformer] cannot parse outputs to proto message, outputs: %v, error: %v\n", tx.Outputs, err)
 		return nil, err
 	}
 
-	return &pb.TxBody{
+	body := &pb.TxBody{
 		Inputs:                transformInputs(tx.Inputs),
 		Outputs:               outputs,
-		Mint:                  transformMint(tx.Mint),
+		Mint:                  transformMint(tx.Mint, opts),
 		ReferenceInputs:       transformReferences(tx.References),
-		ValidityIntervalStart: int64(tx.ValidityInterval.InvalidAfter),
-		ValidityIntervalEnd:   int64(tx.ValidityInterval.InvalidBefore),
-		Withdrawals:           transformWithdrawals(tx.Withdrawals),
-	}, nil
+		ValidityIntervalStart: int64(tx.ValidityInterval.InvalidBefore),
+		ValidityIntervalEnd:   int64(tx.ValidityInterval.InvalidAfter),
+	}
+	if opts.LegacyInt64Quantities {
+		body.Withdrawals = transformLegacyWithdrawals(tx.Withdrawals)
+	} else {
+		body.WithdrawalQuantities = transformWithdrawals(tx.Withdrawals)
+	}
+	return body, nil
+}
+
+// transformTime converts an optional time. Unset and zero times are left unset.
+func transformTime(t *time.Time) *types.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	ts, err := types.TimestampProto(*t)
+	if err != nil {
+		return nil
+	}
+	return ts
 }
 
 func transformInputs(inputs []chainsync.TxIn) []*pb.TxInput {
 	pbInputs := make([]*pb.TxInput, len(inputs))
 	for i, in := range inputs {
 		pbInputs[i] = &pb.TxInput{
-			TransactionID: string(in.TxID()),
+			TransactionId: string(in.TxID()),
 			Index:         int32(in.Index),
 		}
 	}
 	return pbInputs
 }
 
-func transformOutputs(outputs []chainsync.TxOut) ([]*pb.TxOutput, error) {
+func transformOutputs(outputs []chainsync.TxOut, opts Options) ([]*pb.TxOutput, error) {
 	pbOutputs := make([]*pb.TxOutput, len(outputs))
 	for i, out := range outputs {
 		script, err := utils.ConvertToProtobufStruct(out.Script)
@@ -126,55 +196,92 @@ func transformOutputs(outputs []chainsync.TxOut) ([]*pb.TxOutput, error) {
 			Address:   out.Address,
 			Datum:     out.Datum,
 			DatumHash: out.DatumHash,
-			Value:     transformOutputValue(out.Value),
+			Value:     transformOutputValue(out.Value, opts),
 			Script:    script,
+
+			AddressDetails: transformAddressDetails(out.Address),
 		}
 	}
 	return pbOutputs, nil
 }
 
-func transformOutputValue(value shared.Value) *pb.OutputValue {
-	assets := make(map[string]int64)
-	lovelaceAmount := int64(0)
+// transformAddressDetails decodes an address. Addresses that cannot be decoded have no details.
+func transformAddressDetails(addr string) *pb.AddressDetails {
+	details, err := address.Describe(addr)
+	if err != nil {
+		return nil
+	}
+	return &pb.AddressDetails{
+		Type:              string(details.Type),
+		NetworkId:         uint32(details.NetworkID),
+		PaymentCredential: transformCredential(details.PaymentCredential),
+		StakeCredential:   transformCredential(details.StakeCredential),
+		StakeAddress:      details.StakeAddress,
+	}
+}
 
-	for policyId, items := range value {
-		for assetName, qty := range items {
-			if policyId == shared.AdaPolicy && assetName == shared.AdaAsset {
-				// Coins (Lovelace)
-				lovelaceAmount = qty.Int64()
-			} else {
-				assetStr := utils.GetAsset(policyId, assetName)
-				assets[assetStr] = qty.Int64()
-			}
-		}
+func transformCredential(c *address.Credential) *pb.Credential {
+	if c == nil {
+		return nil
 	}
+	return &pb.Credential{Type: string(c.Type), Hash: c.Hash}
+}
 
+func transformOutputValue(value shared.Value, opts Options) *pb.OutputValue {
+	lovelace, assets, quantities := splitValue(value, opts)
 	return &pb.OutputValue{
-		Coins:  lovelaceAmount,
-		Assets: assets,
+		Coins:           lovelace,
+		Assets:          assets,
+		AssetQuantities: quantities,
 	}
 }
 
-func transformMint(value shared.Value) *pb.MintValue {
-	assets := make(map[string]int64)
+func transformMint(value shared.Value, opts Options) *pb.MintValue {
+	lovelace, assets, quantities := splitValue(value, opts)
+	return &pb.MintValue{
+		Coins:           lovelace,
+		Assets:          assets,
+		AssetQuantities: quantities,
+	}
+}
+
+// splitValue separates lovelace from native assets. Asset quantities are returned either as
+// legacy int64 values or as lossless decimal strings, depending on the options.
+func splitValue(value shared.Value, opts Options) (int64, map[string]int64, map[string]string) {
+	var assets map[string]int64
+	var quantities map[string]string
+	if opts.LegacyInt64Quantities {
+		assets = make(map[string]int64)
+	} else {
+		quantities = make(map[string]string)
+	}
 	lovelaceAmount := int64(0)
 
 	for policyId, items := range value {
 		for assetName, qty := range items {
 			if policyId == shared.AdaPolicy && assetName == shared.AdaAsset {
-				// Coins (Lovelace)
+				// Coins (Lovelace); the total supply fits in an int64.
 				lovelaceAmount = qty.Int64()
 			} else {
 				assetStr := utils.GetAsset(policyId, assetName)
-				assets[assetStr] = qty.Int64()
+				if opts.LegacyInt64Quantities {
+					assets[assetStr] = qty.Int64()
+				} else {
+					quantities[assetStr] = qty.String()
+				}
 			}
 		}
 	}
 
-	return &pb.MintValue{
-		Coins:  lovelaceAmount,
-		Assets: assets,
+	// Leave the maps unset rather than empty, so that the value is the same after a protobuf round
+	// trip, which does not distinguish them.
+	if len(assets) == 0 {
+		assets = nil
+	}
+	if len(quantities) == 0 {
+		quantities = nil
 	}
+	return lovelaceAmount, assets, quantities
 }
 
 func transformReferences(refs []chainsync.TxIn) []*pb.Reference {
@@ -205,14 +312,14 @@ func transformReferences(refs []chainsync.TxIn) []*pb.Reference {
 	pbRefs := make([]*pb.Reference, len(refs))
 	for i, ref := range refs {
 		pbRefs[i] = &pb.Reference{
-			TransactionID: ref.Transaction.ID,
+			TransactionId: ref.Transaction.ID,
 			Index:         int32(ref.Index),
 		}
 	}
 	return pbRefs
 }
 
-func transformWithdrawals(withdrawals map[string]shared.Value) map[string]map[string]int64 {
+func transformWithdrawals(withdrawals map[string]shared.Value) map[string]*pb.AssetAmounts {
 	// "withdrawals": {
 	// 	"stake17xd7s38syqung8dqh2eu9erwcejda2y0njle0tt880ljunq6glahd": {
 	// 		"ada": {
@@ -229,22 +336,35 @@ func transformWithdrawals(withdrawals map[string]shared.Value) map[string]map[st
 	if len(withdrawals) == 0 {
 		return nil
 	}
-	results := make(map[string]map[string]int64) // { "$stakeAddr": {"$policyId.$assetName": qty} }
+	results := make(map[string]*pb.AssetAmounts) // { "$stakeAddr": {"$policyId.$assetName": "qty"} }
 	for r, value := range withdrawals {
-		results[r] = transformWithdrawalValue(value)
+		quantities := make(map[string]string)
+		for policyId, items := range value {
+			for assetName, qty := range items {
+				quantities[utils.GetAsset(policyId, assetName)] = qty.String()
+			}
+		}
+		results[r] = &pb.AssetAmounts{Quantities: quantities}
 	}
 	return results
 }
 
-func transformWithdrawalValue(value shared.Value) map[string]int64 {
-	assets := make(map[string]int64)
-	for policyId, items := range value {
-		for assetName, qty := range items {
-			assetStr := utils.GetAsset(policyId, assetName)
-			assets[assetStr] = qty.Int64()
+// transformLegacyWithdrawals converts withdrawals to the deprecated int64 format.
+func transformLegacyWithdrawals(withdrawals map[string]shared.Value) map[string]*pb.LegacyAmounts {
+	if len(withdrawals) == 0 {
+		return nil
+	}
+	results := make(map[string]*pb.LegacyAmounts) // { "$stakeAddr": {"$policyId.$assetName": qty} }
+	for r, value := range withdrawals {
+		assets := make(map[string]int64)
+		for policyId, items := range value {
+			for assetName, qty := range items {
+				assets[utils.GetAsset(policyId, assetName)] = qty.Int64()
+			}
 		}
+		results[r] = &pb.LegacyAmounts{Assets: assets}
 	}
-	return assets
+	return results
 }
 
 func transformRedeemers(redeemersJSON json.RawMessage) (*types.Struct, error) {
@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"sort"
 	"strings"
+
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/shared"
 )
 
 const (
@@ -26,3 +29,16 @@ func ParseAsset(asset string) (string, string) {
 		return asset, EmptyAssetName
 	}
 }
+
+// SortedPolicies returns the policy IDs of the native assets of a value, sorted. This is the ledger
+// order of the policies, which is also the order of mint redeemer indexes.
+func SortedPolicies(value shared.Value) []string {
+	policies := make([]string, 0, len(value))
+	for policyID := range value {
+		if policyID != shared.AdaPolicy {
+			policies = append(policies, policyID)
+		}
+	}
+	sort.Strings(policies)
+	return policies
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"github.com/SundaeSwap-finance/ogmigo/ouroboros/chainsync"
+)
+
+// IsInvalidated tells whether a transaction failed phase-2 validation, in which case Ogmios
+// reports that it spends its collaterals.
+func IsInvalidated(tx chainsync.Tx) bool {
+	return tx.Spends == "collaterals"
+}
+
+// SpentInputs returns the inputs consumed by a transaction: its collaterals when it failed
+// phase-2 validation, its inputs otherwise.
+func SpentInputs(tx chainsync.Tx) []chainsync.TxIn {
+	if IsInvalidated(tx) {
+		return tx.Collaterals
+	}
+	return tx.Inputs
+}
+
+// ProducedOutputs returns the outputs created by a transaction, by output index. A failed
+// transaction only creates its collateral return, indexed after the body outputs.
+func ProducedOutputs(tx chainsync.Tx) map[int]chainsync.TxOut {
+	outputs := make(map[int]chainsync.TxOut)
+	if IsInvalidated(tx) {
+		if tx.CollateralReturn != nil {
+			outputs[len(tx.Outputs)] = *tx.CollateralReturn
+		}
+		return outputs
+	}
+	for i, out := range tx.Outputs {
+		outputs[i] = out
+	}
+	return outputs
+}